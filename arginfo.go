@@ -11,6 +11,7 @@ import (
 var (
 	ContextType = reflect.TypeOf((*context.Context)(nil)).Elem()
 	InfoType    = reflect.TypeOf((*graphql.ResolveInfo)(nil)).Elem()
+	ParamsType  = reflect.TypeOf((*graphql.ResolveParams)(nil)).Elem()
 	ErrorType   = reflect.TypeOf((*error)(nil)).Elem()
 )
 
@@ -20,6 +21,16 @@ type ArgInfo struct {
 	Index    int
 	IsPtr    bool
 	IsSlice  bool
+
+	// DecodeHook is applied by mapstructure when decoding argument maps
+	DecodeHook mapstructure.DecodeHookFunc
+
+	// ErrorUnused fails decoding argument maps with keys matching no field
+	ErrorUnused bool
+
+	// MatchName matches argument keys to field names, which are gql tag
+	// names or Go names, case insensitively if nil
+	MatchName func(mapKey, fieldName string) bool
 }
 
 func NewArgInfo(argType reflect.Type, index int) *ArgInfo {
@@ -38,9 +49,66 @@ func NewArgInfo(argType reflect.Type, index int) *ArgInfo {
 	}
 }
 
+// decode decodes input into the struct pointed to by out using mapstructure,
+// failing on keys matching no field when errorUnused is set
+func decode(input interface{}, out interface{}, hook mapstructure.DecodeHookFunc, errorUnused bool, matchName func(mapKey, fieldName string) bool) error {
+	hooks := []mapstructure.DecodeHookFunc{singleValueToSliceHook, numberToJSONNumberHook}
+	if hook != nil {
+		hooks = append(hooks, hook)
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(hooks...),
+		// Embedded structs read their fields from the same level, matching
+		// the flattened arguments
+		Squash: true,
+		// Arguments are keyed by their gql names, which may differ from
+		// the Go field names
+		TagName:     GqlTagKey,
+		ErrorUnused: errorUnused,
+		MatchName:   matchName,
+		Result:      out,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+// DecodeArgs decodes the resolver arguments into the struct pointed to by out,
+// for resolvers written against graphql-go's native signature
+func DecodeArgs(p graphql.ResolveParams, out interface{}) error {
+	return decode(p.Args, out, nil, false, nil)
+}
+
+// singleValueToSliceHook wraps single values decoded into slice fields in a
+// one element slice, as GraphQL coerces them into one element lists
+func singleValueToSliceHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if data == nil || to.Kind() != reflect.Slice {
+		return data, nil
+	}
+	if from.Kind() == reflect.Slice || from.Kind() == reflect.Array {
+		return data, nil
+	}
+	return []interface{}{data}, nil
+}
+
+// stringToBoolHook decodes "true" and "false" strings into bool fields
+func stringToBoolHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Bool {
+		return data, nil
+	}
+	switch reflect.ValueOf(data).String() {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return data, nil
+}
+
 func (a *ArgInfo) ValueFromMap(m interface{}) (reflect.Value, error) {
 	obj := reflect.New(a.RealType).Interface()
-	err := mapstructure.Decode(m, obj)
+	err := decode(m, obj, a.DecodeHook, a.ErrorUnused, a.MatchName)
 	if err != nil {
 		return reflect.Value{}, err
 	}
@@ -51,11 +119,13 @@ func (a *ArgInfo) ValueFromMap(m interface{}) (reflect.Value, error) {
 }
 
 func (a *ArgInfo) ValueFromSlice(value interface{}) (reflect.Value, error) {
-	length := reflect.ValueOf(value).Len()
+	source := reflect.ValueOf(value)
+	length := source.Len()
 	slice := reflect.MakeSlice(a.Type, length, length)
 	for i := 0; i < length; i++ {
-		elem, err := a.ValueFrom(reflect.ValueOf(value).Index(i).Interface())
-		if err != nil {
+		// Decode each element so decode hooks (e.g. enum names) apply to elements too
+		elem := reflect.New(a.Type.Elem())
+		if err := decode(source.Index(i).Interface(), elem.Interface(), a.DecodeHook, a.ErrorUnused, a.MatchName); err != nil {
 			return reflect.Value{}, err
 		}
 		slice.Index(i).Set(elem.Elem())
@@ -64,15 +134,31 @@ func (a *ArgInfo) ValueFromSlice(value interface{}) (reflect.Value, error) {
 }
 
 func (a *ArgInfo) ValueFrom(value interface{}) (reflect.Value, error) {
+	// Null arguments and missing sources yield the zero value, a nil pointer for pointer types
+	if value == nil {
+		return reflect.Zero(a.Type), nil
+	}
+
+	// Values already of the expected type, such as named slice sources, are used as is
+	if reflect.TypeOf(value) == a.Type {
+		return reflect.ValueOf(value), nil
+	}
+
 	if reflect.TypeOf(value).Kind() == reflect.Ptr {
 		if a.IsPtr {
 			return reflect.ValueOf(value), nil
 		}
+		if reflect.ValueOf(value).IsNil() {
+			return reflect.Zero(a.Type), nil
+		}
 		return reflect.ValueOf(value).Elem(), nil
 	} else if reflect.TypeOf(value).Kind() == reflect.Map {
 		return a.ValueFromMap(value.(map[string]interface{}))
 	} else if reflect.TypeOf(value).Kind() == reflect.Slice {
 		return a.ValueFromSlice(value)
+	} else if a.IsSlice {
+		// Single values are coerced into one element lists
+		return a.ValueFromSlice([]interface{}{value})
 	} else {
 		if a.IsPtr {
 			ptr := reflect.New(a.RealType)
@@ -0,0 +1,315 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type FlagInput struct {
+	Enabled bool `gql:"enabled"`
+}
+
+func TestValueFromMapStringBooleans(t *testing.T) {
+	cases := []struct {
+		stringBooleans bool
+		value          interface{}
+		expected       bool
+		isError        bool
+	}{
+		{stringBooleans: true, value: "true", expected: true},
+		{stringBooleans: true, value: "false", expected: false},
+		{stringBooleans: true, value: true, expected: true},
+		{stringBooleans: false, value: false, expected: false},
+		{stringBooleans: false, value: "true", isError: true},
+	}
+
+	for _, c := range cases {
+		argInfo := NewArgInfo(reflect.TypeOf(FlagInput{}), 1)
+		argInfo.DecodeHook = NewSchemaBuilder().WithStringBooleans(c.stringBooleans).decodeHook()
+
+		value, err := argInfo.ValueFromMap(map[string]interface{}{"enabled": c.value})
+		if c.isError {
+			if err == nil {
+				t.Errorf("expected error for %v, got nil", c.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected no error for %v, got %v", c.value, err)
+			continue
+		}
+
+		if enabled := value.Interface().(FlagInput).Enabled; enabled != c.expected {
+			t.Errorf("expected %t for %v, got %t", c.expected, c.value, enabled)
+		}
+	}
+}
+
+func TestDecodeArgs(t *testing.T) {
+	p := graphql.ResolveParams{
+		Args: map[string]interface{}{
+			"name": "john",
+			"address": map[string]interface{}{
+				"street": "Main St",
+				"city":   "Springfield",
+			},
+		},
+	}
+
+	var input CustomerInput
+	if err := DecodeArgs(p, &input); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := CustomerInput{Name: "john", Address: AddressInput{Street: "Main St", City: "Springfield"}}
+	if !reflect.DeepEqual(input, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, input)
+	}
+}
+
+func TestSchemaBuilderDecodeArgs(t *testing.T) {
+	p := graphql.ResolveParams{
+		Args: map[string]interface{}{"enabled": "true"},
+	}
+
+	var input FlagInput
+	if err := DecodeArgs(p, &input); err == nil {
+		t.Fatalf("expected error without string booleans, got nil")
+	}
+
+	if err := NewSchemaBuilder().WithStringBooleans(true).DecodeArgs(p, &input); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !input.Enabled {
+		t.Fatalf("expected enabled to be true")
+	}
+}
+
+func TestValueFromNil(t *testing.T) {
+	cases := []struct {
+		argType reflect.Type
+		value   interface{}
+	}{
+		{argType: reflect.TypeOf(Tagged{}), value: nil},
+		{argType: reflect.TypeOf(&Tagged{}), value: nil},
+		{argType: reflect.TypeOf(""), value: nil},
+		{argType: reflect.TypeOf(Host{}), value: (*Host)(nil)},
+		{argType: reflect.TypeOf(&Host{}), value: (*Host)(nil)},
+	}
+
+	for _, c := range cases {
+		value, err := NewArgInfo(c.argType, 0).ValueFrom(c.value)
+		if err != nil {
+			t.Errorf("expected no error for %s, got %v", c.argType, err)
+			continue
+		}
+
+		if !value.IsValid() || value.Type() != c.argType {
+			t.Errorf("expected value of type %s, got %v", c.argType, value)
+			continue
+		}
+
+		if !value.IsZero() {
+			t.Errorf("expected zero value of %s, got %v", c.argType, value)
+		}
+	}
+}
+
+type Pagination struct {
+	Limit  int `gql:"limit"`
+	Offset int `gql:"offset"`
+}
+
+type ListPostsInput struct {
+	Pagination
+	Author string `gql:"author"`
+}
+
+type EmbeddedInputHost struct{}
+
+func (h *EmbeddedInputHost) Posts(input ListPostsInput) (string, error) {
+	return fmt.Sprintf("%s %d %d", input.Author, input.Limit, input.Offset), nil
+}
+
+func TestEmbeddedInputStructs(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&EmbeddedInputHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	args := map[string]bool{}
+	for _, arg := range schema.QueryType().Fields()["posts"].Args {
+		args[arg.Name()] = true
+	}
+	expectedArgs := map[string]bool{"limit": true, "offset": true, "author": true}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Fatalf("expected %v, got %v", expectedArgs, args)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ posts(author: "ada", limit: 10, offset: 20) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"posts": "ada 10 20"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type UserID string
+
+type UserIDInput struct {
+	ID     UserID   `gql:"id,nonNull"`
+	Others []UserID `gql:"others"`
+}
+
+type UserIDHost struct{}
+
+func (h *UserIDHost) Node(input UserIDInput) (string, error) {
+	return fmt.Sprintf("%s %v", input.ID, input.Others), nil
+}
+
+func TestNamedStringIDArguments(t *testing.T) {
+	b := NewSchemaBuilder()
+	b.RegisterCustomType(reflect.TypeOf(UserID("")), graphql.ID)
+	schema, err := b.WithQuery(&UserIDHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($id: ID!) { node(id: $id, others: [1, "b"]) }`,
+		VariableValues: map[string]interface{}{"id": "u1"},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"node": "u1 [1 b]"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type RenamedArgsInput struct {
+	UserID    string `gql:"user_id,nonNull"`
+	GroupName string `gql:"group"`
+}
+
+type RenamedArgsHost struct{}
+
+func (h *RenamedArgsHost) Member(input RenamedArgsInput) (string, error) {
+	return input.UserID + "@" + input.GroupName, nil
+}
+
+func TestRenamedArguments(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&RenamedArgsHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ member(user_id: "u1", group: "admins") }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"member": "u1@admins"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestValueFromMapGqlNames(t *testing.T) {
+	argInfo := NewArgInfo(reflect.TypeOf(RenamedArgsInput{}), 1)
+	value, err := argInfo.ValueFromMap(map[string]interface{}{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if userID := value.Interface().(RenamedArgsInput).UserID; userID != "u1" {
+		t.Fatalf("expected u1, got %q", userID)
+	}
+}
+
+type TagsInput struct {
+	Tags []string `gql:"tags"`
+}
+
+func TestSingleValueToList(t *testing.T) {
+	argInfo := NewArgInfo(reflect.TypeOf(TagsInput{}), 1)
+	value, err := argInfo.ValueFromMap(map[string]interface{}{"tags": "go"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tags := value.Interface().(TagsInput).Tags; !reflect.DeepEqual(tags, []string{"go"}) {
+		t.Fatalf("expected [go], got %v", tags)
+	}
+
+	sliceInfo := NewArgInfo(reflect.TypeOf([]string{}), 1)
+	value, err = sliceInfo.ValueFrom("go")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(value.Interface(), []string{"go"}) {
+		t.Fatalf("expected [go], got %v", value.Interface())
+	}
+}
+
+type ThemeSettings struct {
+	Theme string `gql:"theme"`
+}
+
+type SettingsInput struct {
+	Settings ThemeSettings `gql:"settings"`
+}
+
+type SettingsHost struct{}
+
+func (h *SettingsHost) Theme(input SettingsInput) (string, error) {
+	return input.Settings.Theme, nil
+}
+
+func TestWithErrorUnused(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		b := NewSchemaBuilder().WithErrorUnused(strict)
+		b.RegisterCustomType(reflect.TypeOf(ThemeSettings{}), JSON)
+		schema, err := b.WithQuery(&SettingsHost{}).BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: `{ theme(settings: {theme: "dark", font: "mono"}) }`,
+			Context:       context.Background(),
+		})
+		if strict {
+			if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "font") {
+				t.Fatalf("expected unused key error, got %v", result.Errors)
+			}
+			continue
+		}
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+		expected := map[string]interface{}{"theme": "dark"}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("expected %v, got %v", expected, result.Data)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package gql
+
+import "github.com/graphql-go/graphql"
+
+// ArgumentValidator checks the coerced arguments of a field, such as
+// constraints spanning several arguments
+type ArgumentValidator func(args map[string]interface{}) error
+
+// WithArgumentValidator adds a validator of the named field's arguments,
+// run after they are coerced and before the resolver. An error fails the
+// field without calling the resolver.
+func (b *SchemaBuilder) WithArgumentValidator(field string, validator ArgumentValidator) *SchemaBuilder {
+	b.argValidators[field] = append(b.argValidators[field], validator)
+	return b
+}
+
+// withArgumentValidators runs resolve once the arguments pass validators
+func withArgumentValidators(validators []ArgumentValidator, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		for _, validator := range validators {
+			if err := validator(p.Args); err != nil {
+				return nil, err
+			}
+		}
+		return resolve(p)
+	}
+}
@@ -0,0 +1,68 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type RangeInput struct {
+	From int `gql:"from,nonNull"`
+	To   int `gql:"to,nonNull"`
+}
+
+type RangeHost struct {
+	calls int
+}
+
+func (h *RangeHost) Span(input RangeInput) (int, error) {
+	h.calls++
+	return input.To - input.From, nil
+}
+
+func TestWithArgumentValidator(t *testing.T) {
+	host := &RangeHost{}
+	schema, err := NewSchemaBuilder().
+		WithArgumentValidator("span", func(args map[string]interface{}) error {
+			if args["from"].(int) > args["to"].(int) {
+				return errors.New("from must be before to")
+			}
+			return nil
+		}).
+		WithQuery(host).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ span(from: 5, to: 1) }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "from must be before to" {
+		t.Fatalf("expected validation error, got %v", result.Errors)
+	}
+	if host.calls != 0 {
+		t.Fatalf("expected resolver not to be called, got %d calls", host.calls)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ span(from: 1, to: 5) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+	expected := map[string]interface{}{"span": 4}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+	if host.calls != 1 {
+		t.Fatalf("expected 1 resolver call, got %d", host.calls)
+	}
+}
@@ -2,13 +2,17 @@ package gql
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/graphql-go/graphql"
-	"github.com/graphql-go/graphql/language/ast"
+	"github.com/mitchellh/mapstructure"
 )
 
 type RootType string
@@ -20,19 +24,57 @@ const (
 )
 
 type SchemaBuilder struct {
-	query                interface{}
-	mutation             interface{}
-	subscription         interface{}
-	typeRegistry         map[reflect.Type]graphql.Output
-	customTypes          map[reflect.Type]graphql.Output
-	processing           map[reflect.Type]bool           // Track types currently being processed to prevent cycles
-	fieldsCache          map[reflect.Type]graphql.Fields // Cache fields for types being processed
-	rootInstances        map[reflect.Type]interface{}    // Registry for root instances (Query, Mutation)
-	typeHashRegistry     map[string]string               // Map struct hash to canonical GraphQL type name
-	allowSharedTypes     bool                            // Enable/disable type deduplication
-	structHashCache      map[reflect.Type]string         // Cache struct hashes to avoid recalculation
-	inputTypeRegistry    map[reflect.Type]*graphql.InputObject // Cache input objects by Go type
-	hashToInputType      map[string]*graphql.InputObject // Cache input objects by structural hash
+	query             interface{}
+	mutation          interface{}
+	subscription      interface{}
+	typeRegistry      map[reflect.Type]graphql.Output
+	customTypes       map[reflect.Type]graphql.Output
+	processing        map[reflect.Type]bool                   // Track types currently being processed to prevent cycles
+	fieldsCache       map[reflect.Type]graphql.Fields         // Cache fields for types being processed
+	rootInstances     map[reflect.Type]interface{}            // Registry for root instances (Query, Mutation)
+	typeHashRegistry  map[string]string                       // Map struct hash to canonical GraphQL type name
+	allowSharedTypes  bool                                    // Enable/disable type deduplication
+	structHashCache   map[reflect.Type]string                 // Cache struct hashes to avoid recalculation
+	inputTypeRegistry map[reflect.Type]*graphql.InputObject   // Cache input objects by Go type
+	hashToInputType   map[string]*graphql.InputObject         // Cache input objects by structural hash
+	enumValues        map[reflect.Type]map[string]interface{} // Enum value names to Go values by Go type
+	resolveConfig     ResolveConfig                           // Optional resolver signature settings
+	fieldNamer        FieldNamer                              // Derives GraphQL field names from Go method names
+	stringBooleans    bool                                    // Decode "true"/"false" strings into bool arguments
+	errorUnused       bool                                    // Fail decoding arguments with unknown keys
+	nonNullLists      bool                                    // Map value slices to non-null lists
+	nonNullElements   bool                                    // Map value elements of lists to non-null
+	nullablePointers  bool                                    // Keep pointer fields nullable regardless of tags
+	nullableResolvers bool                                    // Keep resolver method fields nullable
+	defaultResolver   bool                                    // Resolve tagged struct fields by their Go field
+	fieldCaches       map[string]*fieldCache                  // Result caches by field name
+	fieldTimeouts     map[string]time.Duration                // Resolution timeouts by field name
+	metrics           MetricsRecorder                         // Receives resolver measurements when set
+	fieldCosts        map[string]int                          // Query complexity costs by Type.field
+	costEstimator     ComplexityEstimator                     // Estimates field costs, overriding cost tags
+	maxCost           int                                     // Maximum operation cost, unlimited when zero
+	extraTypes        []graphql.Type                          // Hand-built types added to the schema config
+	directives        []*graphql.Directive                    // Custom directives added to the schema config
+	extensions        []graphql.Extension                     // Execution extensions added to the schema config
+	boundResolvers    []boundResolver                         // Methods of existing values exposed as query fields
+	mapTypes          map[string]*graphql.Object              // Map entry list objects by value type name
+	lazyFields        bool                                    // Build object fields in thunks
+	lazyBuilding      map[reflect.Type]bool                   // Types whose field thunks are running
+	lazyErrors        []error                                 // Errors raised by field thunks
+	middlewares       []ContextMiddleware                     // Derive the contexts passed to resolvers
+	fieldMiddlewares  map[string][]FieldMiddleware            // Resolver middlewares by field name
+	argValidators     map[string][]ArgumentValidator          // Argument validators by field name
+	int64Scalar       bool                                    // Map int64 and uint64 to the Long scalar
+	panicHandler      PanicHandler                            // Recovers resolver panics when set
+	logger            Logger                                  // Receives build diagnostics when set
+	descriptions      map[string]string                       // Descriptions by Go Type or Type.Member name
+	rootNames         map[RootType]string                     // Root object name overrides
+	rootTypeNames     map[reflect.Type]string                 // Root object name overrides by Go type
+	inputDefaultTypes map[reflect.Type]bool                   // Input types providing defaults by a Defaults method
+	defaultsGetters   map[reflect.Type]bool                   // Types exposing a Defaults method as a getter
+	specifiedByURLs   map[*graphql.Scalar]string              // Scalar specification URLs printed in SDL
+	typeExtensions    map[*graphql.Object][]string            // Names of the fields added by ExtendType
+	oneOfInputs       map[*graphql.InputObject]bool           // Input objects printed with @oneOf in SDL
 }
 
 func NewSchemaBuilder() *SchemaBuilder {
@@ -44,16 +86,37 @@ func NewSchemaBuilder() *SchemaBuilder {
 		rootInstances:     make(map[reflect.Type]interface{}),
 		typeHashRegistry:  make(map[string]string),
 		allowSharedTypes:  true, // Enable by default
+		defaultResolver:   true,
 		structHashCache:   make(map[reflect.Type]string),
 		inputTypeRegistry: make(map[reflect.Type]*graphql.InputObject),
 		hashToInputType:   make(map[string]*graphql.InputObject),
+		enumValues:        make(map[reflect.Type]map[string]interface{}),
+		fieldNamer:        LowerCamelCase,
+		fieldCaches:       make(map[string]*fieldCache),
+		fieldTimeouts:     make(map[string]time.Duration),
+		fieldCosts:        make(map[string]int),
+		mapTypes:          make(map[string]*graphql.Object),
+		lazyBuilding:      make(map[reflect.Type]bool),
+		fieldMiddlewares:  make(map[string][]FieldMiddleware),
+		argValidators:     make(map[string][]ArgumentValidator),
+		rootNames:         make(map[RootType]string),
+		rootTypeNames:     make(map[reflect.Type]string),
+		inputDefaultTypes: make(map[reflect.Type]bool),
+		defaultsGetters:   make(map[reflect.Type]bool),
+		specifiedByURLs:   make(map[*graphql.Scalar]string),
+		typeExtensions:    make(map[*graphql.Object][]string),
+		oneOfInputs:       make(map[*graphql.InputObject]bool),
 	}
 
 	// Register default custom types (standard library types only)
 	// Framework-specific types (e.g., gorm.DeletedAt) should be registered
 	// by the application using RegisterCustomType()
-	sb.RegisterCustomType(reflect.TypeOf(time.Time{}), createDateTimeScalar())
-	sb.RegisterCustomType(reflect.TypeOf((*time.Time)(nil)).Elem(), createDateTimeScalar())
+	sb.RegisterCustomType(reflect.TypeOf(time.Time{}), graphql.DateTime)
+	sb.RegisterCustomType(reflect.TypeOf(&time.Time{}), graphql.DateTime)
+	sb.RegisterCustomType(reflect.TypeOf(net.IP{}), createIPScalar())
+	sb.RegisterCustomType(reflect.TypeOf(url.URL{}), createURLScalar())
+	sb.RegisterCustomType(reflect.TypeOf(Upload{}), UploadScalar)
+	sb.RegisterCustomType(jsonNumberType, JSONNumber)
 
 	return sb
 }
@@ -63,12 +126,209 @@ func (b *SchemaBuilder) RegisterCustomType(goType reflect.Type, graphqlType grap
 	b.customTypes[goType] = graphqlType
 }
 
+// RegisteredTypes returns a copy of the Go types mapped to GraphQL object
+// types so far, typically inspected after BuildSchema by documentation tools
+func (b *SchemaBuilder) RegisteredTypes() map[reflect.Type]graphql.Output {
+	types := make(map[reflect.Type]graphql.Output, len(b.typeRegistry))
+	for goType, graphqlType := range b.typeRegistry {
+		types[goType] = graphqlType
+	}
+	return types
+}
+
+// WithStringerScalar maps goType, typically an interface such as fmt.Stringer
+// or a type implementing it, to the String scalar so that values are
+// serialized through their String method
+func (b *SchemaBuilder) WithStringerScalar(goType reflect.Type) *SchemaBuilder {
+	b.RegisterCustomType(goType, graphql.String)
+	return b
+}
+
+// WithScalar maps goType to a custom scalar created from config, whose
+// Serialize, ParseValue and ParseLiteral functions control its coercion.
+// Without ParseLiteral, literals are coerced through ParseValue.
+func (b *SchemaBuilder) WithScalar(goType reflect.Type, config graphql.ScalarConfig) *SchemaBuilder {
+	if config.ParseLiteral == nil && config.ParseValue != nil {
+		config.ParseLiteral = literalParser(config.ParseValue)
+	}
+	b.RegisterCustomType(goType, graphql.NewScalar(config))
+	return b
+}
+
 // AllowSharedTypes enables or disables type deduplication
 func (b *SchemaBuilder) AllowSharedTypes(allow bool) *SchemaBuilder {
 	b.allowSharedTypes = allow
 	return b
 }
 
+// WithFieldNamer sets the strategy deriving GraphQL field names from Go method names
+func (b *SchemaBuilder) WithFieldNamer(namer FieldNamer) *SchemaBuilder {
+	b.fieldNamer = namer
+	return b
+}
+
+// WithExposeAllFields exposes untagged exported struct fields under names
+// derived by the field namer, fields tagged "-" stay hidden
+func (b *SchemaBuilder) WithExposeAllFields(enabled bool) *SchemaBuilder {
+	b.resolveConfig.ExposeAllFields = enabled
+	return b
+}
+
+// exposedFieldName returns the GraphQL name of a struct field given its tag
+// name, deriving it for untagged exported fields when all fields are exposed
+func (b *SchemaBuilder) exposedFieldName(field *reflect.StructField, tagName string) string {
+	if tagName == "" && b.resolveConfig.ExposeAllFields && field.IsExported() && !field.Anonymous {
+		return b.fieldNamer(field.Name)
+	}
+	return tagName
+}
+
+// matchName matches argument keys to the names exposedFieldName derives for
+// untagged fields, nil when fields aren't exposed by default
+func (b *SchemaBuilder) matchName() func(mapKey, fieldName string) bool {
+	if !b.resolveConfig.ExposeAllFields {
+		return nil
+	}
+	namer := b.fieldNamer
+	return func(mapKey, fieldName string) bool {
+		return strings.EqualFold(mapKey, fieldName) || mapKey == namer(fieldName)
+	}
+}
+
+// WithErrorOnlyResolvers enables or disables resolvers returning only an
+// error, such as func(input) error mutations, exposed as Boolean fields
+// resolving to true on success
+func (b *SchemaBuilder) WithErrorOnlyResolvers(enabled bool) *SchemaBuilder {
+	b.resolveConfig.ErrorOnly = enabled
+	return b
+}
+
+// WithScalarInputs enables or disables single non-struct resolver inputs,
+// exposed as one argument named by DefaultScalarInputName
+func (b *SchemaBuilder) WithScalarInputs(enabled bool) *SchemaBuilder {
+	b.resolveConfig.ScalarInputs = enabled
+	return b
+}
+
+// WithStringBooleans enables or disables decoding "true"/"false" strings into
+// bool argument fields. It is disabled by default to avoid masking client bugs.
+func (b *SchemaBuilder) WithStringBooleans(enabled bool) *SchemaBuilder {
+	b.stringBooleans = enabled
+	return b
+}
+
+// WithErrorUnused enables or disables failing to decode arguments with keys
+// matching no input field. graphql-go rejects unknown arguments, but keys
+// nested in JSON scalar arguments are otherwise silently ignored.
+func (b *SchemaBuilder) WithErrorUnused(enabled bool) *SchemaBuilder {
+	b.errorUnused = enabled
+	return b
+}
+
+// WithContextProvider registers a provider populating resolver parameters of
+// goType from the request context
+func (b *SchemaBuilder) WithContextProvider(goType reflect.Type, provider ContextProvider) *SchemaBuilder {
+	if b.resolveConfig.ContextProviders == nil {
+		b.resolveConfig.ContextProviders = make(map[reflect.Type]ContextProvider)
+	}
+	b.resolveConfig.ContextProviders[goType] = provider
+	return b
+}
+
+// WithNonNullLists enables or disables mapping value slices ([]T) to non-null
+// lists ([T]!). Pointers to slices (*[]T) always map to nullable lists and the
+// nullable tag modifier opts a single field out.
+func (b *SchemaBuilder) WithNonNullLists(enabled bool) *SchemaBuilder {
+	b.nonNullLists = enabled
+	return b
+}
+
+// WithNonNullElements enables or disables deriving the nullability of list
+// elements from the element type: values ([]string) map to non-null elements
+// ([String!]) while pointers ([]*string) stay nullable ([String])
+func (b *SchemaBuilder) WithNonNullElements(enabled bool) *SchemaBuilder {
+	b.nonNullElements = enabled
+	return b
+}
+
+// WithNullablePointers enables or disables keeping pointer struct fields
+// nullable even when tagged nonNull, so that nil pointers resolve to null
+// instead of failing the non-null check
+func (b *SchemaBuilder) WithNullablePointers(enabled bool) *SchemaBuilder {
+	b.nullablePointers = enabled
+	return b
+}
+
+// wrapResolver applies the resolver wrappers configured for fieldName
+func (b *SchemaBuilder) wrapResolver(fieldName string, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	// Cached innermost so that middlewares and validators run on cache hits
+	if cache, ok := b.fieldCaches[fieldName]; ok {
+		resolve = cache.wrap(resolve)
+	}
+	// Recovered next so that panics of resolvers run by timeouts are caught
+	if b.panicHandler != nil {
+		resolve = withPanicHandler(b.panicHandler, resolve)
+	}
+	if validators, ok := b.argValidators[fieldName]; ok {
+		resolve = withArgumentValidators(validators, resolve)
+	}
+	if len(b.middlewares) > 0 {
+		resolve = withContextMiddlewares(b.middlewares, resolve)
+	}
+	if timeout, ok := b.fieldTimeouts[fieldName]; ok {
+		resolve = withTimeout(fieldName, timeout, resolve)
+	}
+	if b.metrics != nil {
+		resolve = withMetrics(fieldName, b.metrics, resolve)
+	}
+	// The first middleware added runs first
+	middlewares := b.fieldMiddlewares[fieldName]
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		resolve = middlewares[i](resolve)
+	}
+	return resolve
+}
+
+// newResolveInfo creates a ResolveInfo for fn configured with the builder's
+// argument decoding settings
+func (b *SchemaBuilder) newResolveInfo(fn reflect.Value) (*ResolveInfo, error) {
+	return b.configureResolveInfo(NewResolveInfoWithConfig(fn, &b.resolveConfig))
+}
+
+// newFuncResolveInfo is like newResolveInfo for functions without receiver
+func (b *SchemaBuilder) newFuncResolveInfo(fn reflect.Value) (*ResolveInfo, error) {
+	return b.configureResolveInfo(NewFuncResolveInfo(fn, &b.resolveConfig))
+}
+
+func (b *SchemaBuilder) configureResolveInfo(resolveInfo *ResolveInfo, err error) (*ResolveInfo, error) {
+	if err != nil {
+		return nil, err
+	}
+	if resolveInfo.Input != nil {
+		resolveInfo.Input.DecodeHook = b.decodeHook()
+		resolveInfo.Input.ErrorUnused = b.errorUnused
+		resolveInfo.Input.MatchName = b.matchName()
+	}
+	return resolveInfo, nil
+}
+
+// DecodeArgs is like the package level DecodeArgs but applies the builder's
+// argument decoding settings, such as enum and string boolean decoding
+func (b *SchemaBuilder) DecodeArgs(p graphql.ResolveParams, out interface{}) error {
+	return decode(p.Args, out, b.decodeHook(), b.errorUnused, b.matchName())
+}
+
+// decodeHook composes the decode hooks used when decoding resolver arguments
+func (b *SchemaBuilder) decodeHook() mapstructure.DecodeHookFunc {
+	hooks := []mapstructure.DecodeHookFunc{
+		b.enumDecodeHook,
+	}
+	if b.stringBooleans {
+		hooks = append(hooks, stringToBoolHook)
+	}
+	return mapstructure.ComposeDecodeHookFunc(hooks...)
+}
+
 // structHash computes a hash of a struct's fields for deduplication
 // This hash represents the structural identity of a type (field names and types)
 func (b *SchemaBuilder) structHash(definition reflect.Type) string {
@@ -83,7 +343,11 @@ func (b *SchemaBuilder) structHash(definition reflect.Type) string {
 
 	for _, field := range reflect.VisibleFields(definition) {
 		fieldName, _, err := GetGqlTag(&field)
-		if err != nil || fieldName == "" || fieldName == "-" {
+		if err != nil {
+			continue
+		}
+		fieldName = b.exposedFieldName(&field, fieldName)
+		if fieldName == "" || fieldName == "-" {
 			continue
 		}
 		// Include field name and type in hash
@@ -95,47 +359,57 @@ func (b *SchemaBuilder) structHash(definition reflect.Type) string {
 	return hash
 }
 
-// createDateTimeScalar creates a DateTime scalar for time.Time
-func createDateTimeScalar() *graphql.Scalar {
-	return graphql.NewScalar(graphql.ScalarConfig{
-		Name:        "DateTime",
-		Description: "DateTime scalar type (RFC3339 format)",
-		Serialize: func(value interface{}) interface{} {
-			switch v := value.(type) {
-			case time.Time:
-				return v.Format(time.RFC3339)
-			case *time.Time:
-				if v == nil {
-					return nil
-				}
-				return v.Format(time.RFC3339)
-			default:
-				return nil
-			}
-		},
-		ParseValue: func(value interface{}) interface{} {
-			switch v := value.(type) {
-			case string:
-				t, err := time.Parse(time.RFC3339, v)
-				if err != nil {
-					return nil
-				}
-				return t
-			default:
-				return nil
-			}
-		},
-		ParseLiteral: func(valueAST ast.Value) interface{} {
-			if strValue, ok := valueAST.(*ast.StringValue); ok {
-				t, err := time.Parse(time.RFC3339, strValue.Value)
-				if err != nil {
-					return nil
-				}
-				return t
-			}
-			return nil
-		},
-	})
+// nonNullType wraps t in NonNull unless it is already non-null
+func nonNullType(t graphql.Output) graphql.Output {
+	if _, ok := t.(*graphql.NonNull); ok {
+		return t
+	}
+	return graphql.NewNonNull(t)
+}
+
+// nonNullItemsType makes the elements of the list type t non-null, keeping
+// the nullability of the list itself
+func nonNullItemsType(t graphql.Output) (graphql.Output, error) {
+	nonNull, isNonNull := t.(*graphql.NonNull)
+	if isNonNull {
+		t = nonNull.OfType
+	}
+
+	list, ok := t.(*graphql.List)
+	if !ok {
+		return nil, fmt.Errorf("nonNullItems requires a list type, got %s", t)
+	}
+	t = graphql.NewList(nonNullType(list.OfType))
+
+	if isNonNull {
+		t = graphql.NewNonNull(t)
+	}
+	return t, nil
+}
+
+// isNillableKind reports whether values of kind may be nil
+func isNillableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
+
+// nullableType unwraps t if it is non-null
+func nullableType(t graphql.Output) graphql.Output {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		return nonNull.OfType
+	}
+	return t
+}
+
+// WithRootName overrides the GraphQL object name of a root type, which
+// otherwise is the name of the Go type passed to WithQuery, WithMutation or
+// WithSubscription
+func (b *SchemaBuilder) WithRootName(kind RootType, name string) *SchemaBuilder {
+	b.rootNames[kind] = name
+	return b
 }
 
 func (b *SchemaBuilder) WithQuery(query interface{}) *SchemaBuilder {
@@ -175,9 +449,28 @@ func (b *SchemaBuilder) WithSubscription(subscription interface{}) *SchemaBuilde
 }
 
 func (b *SchemaBuilder) BuildSchemaConfig() (*graphql.SchemaConfig, error) {
+	if b.query == nil && b.mutation == nil && b.subscription == nil && len(b.boundResolvers) == 0 {
+		return nil, errors.New("no root fields defined, use WithQuery, WithMutation or WithSubscription")
+	}
+	start := time.Now()
 
 	var queryObject, mutationObject, subscriptionObject *graphql.Object
 
+	roots := map[RootType]interface{}{
+		Query:        b.query,
+		Mutation:     b.mutation,
+		Subscription: b.subscription,
+	}
+	for kind, name := range b.rootNames {
+		if root := roots[kind]; root != nil {
+			t := reflect.TypeOf(root)
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			b.rootTypeNames[t] = name
+		}
+	}
+
 	if b.query != nil {
 		graphqlField, err := b.TypeAsGraphqlField(reflect.TypeOf(b.query))
 		if err != nil {
@@ -202,11 +495,117 @@ func (b *SchemaBuilder) BuildSchemaConfig() (*graphql.SchemaConfig, error) {
 		subscriptionObject = graphqlField.Type.(*graphql.Object)
 	}
 
-	return &graphql.SchemaConfig{
+	queryObject, err := b.addBoundResolvers(queryObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query type: %w", err)
+	}
+	if queryObject == nil {
+		queryObject = b.placeholderQuery()
+	}
+
+	b.pruneDefaultsGetters()
+
+	if b.lazyFields {
+		if err := b.buildLazyObjects(); err != nil {
+			return nil, err
+		}
+	}
+
+	b.namespaceInputTypes()
+	b.limitSubscriptionComplexity(subscriptionObject)
+
+	schemaConfig := &graphql.SchemaConfig{
 		Query:        queryObject,
 		Mutation:     mutationObject,
 		Subscription: subscriptionObject,
-	}, nil
+		Types:        b.extraTypes,
+		Extensions:   b.schemaExtensions(),
+	}
+
+	// Directives replace the specified ones in graphql-go, so they're kept alongside
+	if len(b.directives) > 0 {
+		schemaConfig.Directives = append(append([]*graphql.Directive{}, graphql.SpecifiedDirectives...), b.directives...)
+	}
+
+	b.logf("gql: built schema config with %d object types in %s", len(b.typeRegistry), time.Since(start))
+	return schemaConfig, nil
+}
+
+// PlaceholderFieldName is the name of the single field of the Query object
+// created for schemas without query fields, such as subscription-only
+// schemas, as graphql-go requires one. The field always resolves to null and
+// is left out by ObjectFields and Stitch.
+const PlaceholderFieldName = "_placeholder"
+
+// placeholderQuery creates the Query object of schemas without query fields
+func (b *SchemaBuilder) placeholderQuery() *graphql.Object {
+	name := string(Query)
+	if rootName, ok := b.rootNames[Query]; ok {
+		name = rootName
+	}
+	return placeholderObject(name)
+}
+
+// placeholderObject creates an object named name holding only the
+// placeholder field
+func placeholderObject(name string) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			PlaceholderFieldName: &graphql.Field{
+				Type:        graphql.Boolean,
+				Description: "Placeholder field of a schema without queries",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nil, nil
+				},
+			},
+		},
+	})
+}
+
+// isPlaceholderObject reports whether object holds only the placeholder field
+func isPlaceholderObject(object *graphql.Object) bool {
+	fields := object.Fields()
+	_, ok := fields[PlaceholderFieldName]
+	return ok && len(fields) == 1
+}
+
+// WithTypes adds hand-built types to the schema config, such as object types
+// only reachable through interfaces or types used by hand-built fields
+func (b *SchemaBuilder) WithTypes(types ...graphql.Type) *SchemaBuilder {
+	b.extraTypes = append(b.extraTypes, types...)
+	return b
+}
+
+// WithDirectives adds custom directives to the schema config next to the
+// directives specified by GraphQL (include, skip, deprecated)
+func (b *SchemaBuilder) WithDirectives(directives ...*graphql.Directive) *SchemaBuilder {
+	b.directives = append(b.directives, directives...)
+	return b
+}
+
+// WithExtensions adds graphql-go extensions to the schema config, whose hooks
+// run around parsing, validation, execution and field resolution, such as
+// for tracing
+func (b *SchemaBuilder) WithExtensions(extensions ...graphql.Extension) *SchemaBuilder {
+	b.extensions = append(b.extensions, extensions...)
+	return b
+}
+
+// namespaceInputTypes suffixes input objects with Input when their name is
+// taken by an object type, so that a struct used both as an argument and as
+// an output maps to distinct types such as UserInput and User
+func (b *SchemaBuilder) namespaceInputTypes() {
+	objectNames := map[string]bool{}
+	for _, graphqlType := range b.typeRegistry {
+		objectNames[graphqlType.Name()] = true
+	}
+
+	for _, inputObject := range b.inputTypeRegistry {
+		if objectNames[inputObject.PrivateName] {
+			inputObject.PrivateName += "Input"
+		}
+	}
 }
 
 func (b *SchemaBuilder) BuildSchema() (*graphql.Schema, error) {
@@ -229,6 +628,31 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 		}, nil
 	}
 
+	// Readers are read fully into strings when resolved
+	if isReaderType(definition) {
+		return &graphql.Field{
+			Type: graphql.String,
+		}, nil
+	}
+
+	// Types round-tripping through text map to string scalars
+	if isTextScalarType(definition) {
+		return &graphql.Field{
+			Type: b.textScalar(definition),
+		}, nil
+	}
+	if definition.Kind() == reflect.Ptr && isTextScalarType(definition.Elem()) {
+		return &graphql.Field{
+			Type: b.textScalar(definition.Elem()),
+		}, nil
+	}
+
+	if b.isLongType(definition) {
+		return &graphql.Field{
+			Type: Long,
+		}, nil
+	}
+
 	switch definition.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return &graphql.Field{
@@ -250,19 +674,39 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 		return &graphql.Field{
 			Type: graphql.Float,
 		}, nil
+	case reflect.Interface:
+		// Empty interfaces hold arbitrary values, serialized as JSON
+		if definition.NumMethod() == 0 {
+			return &graphql.Field{
+				Type: JSON,
+			}, nil
+		}
+		return nil, fmt.Errorf("Unsupported type: %s", definition)
 	case reflect.Slice, reflect.Array:
+		// Named slices with resolver methods become objects exposing their items
+		if field, ok, err := b.namedSliceAsGraphqlField(definition); ok || err != nil {
+			return field, err
+		}
+
 		elemField, err := b.TypeAsGraphqlField(definition.Elem())
 		if err != nil {
 			return nil, err
 		}
+		elemType := elemField.Type
+		if b.nonNullElements && !isNillableKind(definition.Elem().Kind()) {
+			elemType = nonNullType(elemType)
+		}
+		listType := graphql.Output(graphql.NewList(elemType))
+		// Value slices are non-null lists when enabled, pointers to slices stay nullable
+		if b.nonNullLists {
+			listType = graphql.NewNonNull(listType)
+		}
 		return &graphql.Field{
-			Type: graphql.NewList(elemField.Type),
+			Type: listType,
 		}, nil
 	case reflect.Map:
-		// Maps are not directly supported in GraphQL
-		// They should be excluded using gql:"-" tag
-		// If we reach here, it means a map type was encountered without exclusion
-		return nil, fmt.Errorf("map types are not supported in GraphQL schema. Use gql:\"-\" tag to exclude map fields")
+		// Maps with string keys are exposed as lists of entries
+		return b.mapAsGraphqlField(definition)
 	// struct or pointer to struct including slices
 	case reflect.Struct, reflect.Ptr:
 		realDefinition := definition
@@ -278,15 +722,34 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 			}
 
 			if realDefinition.Kind() != reflect.Struct {
-				return b.TypeAsGraphqlField(realDefinition)
+				graphqlField, err := b.TypeAsGraphqlField(realDefinition)
+				if err != nil {
+					return nil, err
+				}
+				// Pointers are always nullable
+				graphqlField.Type = nullableType(graphqlField.Type)
+				return graphqlField, nil
 			}
 		}
 
-		// Check if this type is already registered (prevents infinite recursion)
-		if existingType, ok := b.typeRegistry[realDefinition]; ok {
+		// Structs serializing themselves without gql tags map to the JSON scalar
+		if isJSONMarshalerStruct(realDefinition) {
+			return &graphql.Field{
+				Type: JSON,
+			}, nil
+		}
+
+		// Check if this type is already registered (prevents infinite recursion),
+		// unless the field thunk of the registered lazy object is building it
+		if existingType, ok := b.typeRegistry[realDefinition]; ok && !b.lazyBuilding[realDefinition] {
 			return &graphql.Field{Type: existingType}, nil
 		}
 
+		// Lazy objects are registered right away, their fields built by a thunk
+		if _, isRoot := b.rootInstances[realDefinition]; b.lazyFields && !isRoot && !b.lazyBuilding[realDefinition] {
+			return &graphql.Field{Type: b.lazyObject(definition, realDefinition)}, nil
+		}
+
 		// Check if this type is currently being processed (circular reference)
 		if b.processing[realDefinition] {
 			// For circular references, return the placeholder that was already created
@@ -298,7 +761,8 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 			builderRef := b
 			typeRef := realDefinition
 			placeholder := graphql.NewObject(graphql.ObjectConfig{
-				Name: realDefinition.Name(),
+				Name:        b.objectTypeName(realDefinition),
+				Description: b.descriptions[realDefinition.Name()],
 				Fields: graphql.FieldsThunk(func() graphql.Fields {
 					// Read fields from cache (populated when processing completes)
 					if fields, ok := builderRef.fieldsCache[typeRef]; ok {
@@ -316,29 +780,97 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 		defer func() {
 			delete(b.processing, realDefinition)
 		}()
+		b.logf("gql: building object %s from %s", b.objectTypeName(realDefinition), realDefinition)
 
 		fields := graphql.Fields{}
+		nonNullTags := map[string]bool{} // Fields made non-null by their tag
 		for _, field := range reflect.VisibleFields(realDefinition) {
-			fieldName, isNonNull, err := GetGqlTag(&field)
+			gqlTag, err := ParseGqlTagFromField(&field)
 			if err != nil {
 				return nil, err
 			}
+			fieldName := gqlTag.FieldName
+
+			if cost, ok := gqlTag.Option("cost"); ok && fieldName != "" {
+				value, err := strconv.Atoi(cost)
+				if err != nil {
+					return nil, &tagError{owner: realDefinition, field: field.Name, err: err}
+				}
+				b.fieldCosts[b.objectTypeName(realDefinition)+"."+fieldName] = value
+			}
+
+			// func-typed fields are resolvers, exposed unless tagged "-"
+			if field.Type.Kind() == reflect.Func && field.IsExported() && fieldName != "-" {
+				if fieldName == "" {
+					fieldName = b.fieldNamer(field.Name)
+				}
+				graphqlField, err := b.funcFieldAsGraphqlField(realDefinition, field, fieldName)
+				if err != nil {
+					// Untagged funcs may be unrelated callbacks, only tagged ones must be resolvers
+					if gqlTag.FieldName == "" && !errors.Is(err, errNilFuncField) {
+						continue
+					}
+					return nil, err
+				}
+				// The non-null tag applies to the func's output, as for resolver methods
+				if _, isNonNull := graphqlField.Type.(*graphql.NonNull); gqlTag.IsNonNull() && !isNonNull && !b.nullableResolvers {
+					graphqlField.Type = nonNullType(graphqlField.Type)
+					guardNonNull(graphqlField)
+				}
+				fields[fieldName] = graphqlField
+				continue
+			}
 
 			// if the tag is empty or "-", skip the field, we're interested in fields with a gql tag
+			fieldName = b.exposedFieldName(&field, fieldName)
 			if fieldName == "" || fieldName == "-" {
 				continue
 			}
 
+			// Fields tagged from= alias another Go field, read in its place
+			from, isAlias := gqlTag.Option("from")
+			if isAlias {
+				source, ok := realDefinition.FieldByName(from)
+				if !ok {
+					return nil, fmt.Errorf("field %s: from=%s names no field of %s", field.Name, from, realDefinition)
+				}
+				field = source
+			}
+
 			graphqlField, err := b.TypeAsGraphqlField(field.Type)
 			if err != nil {
 				return nil, err
 			}
 
 			graphqlField.Name = fieldName
+			b.describeField(graphqlField, realDefinition, field.Name)
+			if b.defaultResolver || isAlias {
+				bound, isBound := b.rootInstances[realDefinition]
+				graphqlField.Resolve = structFieldResolver(field.Name, bound, isBound)
+			}
+			if graphqlField.Resolve == nil {
+				graphqlField.Resolve = graphql.DefaultResolveFn
+			}
+			graphqlField.Resolve = b.wrapResolver(fieldName, graphqlField.Resolve)
+			guardIntRange(graphqlField, field.Type)
+			unwrapNamedBasic(graphqlField, field.Type)
+
+			if b.nullablePointers && field.Type.Kind() == reflect.Ptr {
+				graphqlField.Type = nullableType(graphqlField.Type)
+			} else if gqlTag.IsNonNull() {
+				graphqlField.Type = nonNullType(graphqlField.Type)
+				nonNullTags[fieldName] = true
+			} else if gqlTag.IsNullable() {
+				graphqlField.Type = nullableType(graphqlField.Type)
+			}
 
-			if isNonNull {
-				graphqlField.Type = graphql.NewNonNull(graphqlField.Type)
+			if gqlTag.IsNonNullItems() {
+				graphqlField.Type, err = nonNullItemsType(graphqlField.Type)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", field.Name, err)
+				}
 			}
+			guardNonNull(graphqlField)
 
 			fields[fieldName] = graphqlField
 		}
@@ -347,30 +879,32 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 			method := definition.Method(i)
 			if method.IsExported() {
 				// Try full resolver signature first (context, args, error return)
-				resolveInfo, err := NewResolveInfo(method.Func)
+				resolveInfo, err := b.newResolveInfo(method.Func)
+				var tagErr *tagError
+				if errors.As(err, &tagErr) {
+					return nil, fmt.Errorf("failed to build field %s: %w", b.fieldNamer(method.Name), err)
+				}
 				if err == nil {
-					// Full resolver method matched
+					// Full resolver method matched, it overrides a tagged struct field
+					// of the same name and may return a different type
 					// Check if we have a bound instance for this type
 					if instance, ok := b.rootInstances[realDefinition]; ok {
 						val := reflect.ValueOf(instance)
 						resolveInfo.BoundReceiver = &val
 					}
 
-					fieldName := strings.ToLower(method.Name[0:1]) + method.Name[1:]
+					fieldName := b.fieldNamer(method.Name)
 
-					graphqlField, err := b.TypeAsGraphqlField(resolveInfo.Output.Type)
+					graphqlField, err := b.resolverAsGraphqlField(fieldName, resolveInfo)
 					if err != nil {
 						return nil, err
 					}
-
-					graphqlField.Name = fieldName
-					graphqlField.Resolve = resolveInfo.Resolve
-					if resolveInfo.Input != nil {
-						err := b.populateGraphqlFieldArgs(graphqlField, resolveInfo.Input.Type)
-						if err != nil {
-							return nil, err
-						}
+					// The non-null tag of an overridden field applies to the resolver's output
+					if _, isNonNull := graphqlField.Type.(*graphql.NonNull); nonNullTags[fieldName] && !isNonNull && !b.nullableResolvers {
+						graphqlField.Type = nonNullType(graphqlField.Type)
+						guardNonNull(graphqlField)
 					}
+					b.describeField(graphqlField, realDefinition, method.Name)
 					fields[fieldName] = graphqlField
 					continue
 				}
@@ -398,28 +932,29 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 						if _, ok := b.customTypes[returnType]; !ok {
 							if _, ok := b.customTypes[realReturnType]; !ok {
 								// It's a struct without custom type - check for gql tags
-								if !hasStructValidGqlTag(realReturnType) {
+								if !hasExposedFields(realReturnType, b.resolveConfig.ExposeAllFields) && !hasExposedMethods(realReturnType) && !isJSONMarshalerStruct(realReturnType) && !isTextScalarType(realReturnType) {
 									continue
 								}
 							}
 						}
 					}
 
-					fieldName := strings.ToLower(method.Name[0:1]) + method.Name[1:]
-
-					// Skip common non-field methods
-					skipMethods := map[string]bool{
-						"tableName": true, "tableNames": true,
-						"beforeCreate": true, "afterCreate": true,
-						"beforeUpdate": true, "afterUpdate": true,
-						"beforeDelete": true, "afterDelete": true,
-						"beforeSave": true, "afterSave": true,
-						"afterFind":       true,
-						"string":          true,
-						"graphQLTypeName": true,
-						"getGroups":       true, // Already exposed via Groups field
+					fieldName := b.fieldNamer(method.Name)
+
+					if skippedMethods[strings.ToLower(method.Name[0:1])+method.Name[1:]] {
+						continue
+					}
+					// The Defaults method of input types provides their default values,
+					// types found to be inputs later are pruned by pruneDefaultsGetters
+					if isInputDefaultsMethod(realDefinition, method) {
+						if b.inputDefaultTypes[realDefinition] {
+							continue
+						}
+						b.defaultsGetters[realDefinition] = true
 					}
-					if skipMethods[fieldName] {
+
+					// Getters only back virtual fields, tagged struct fields take precedence
+					if _, exists := fields[fieldName]; exists {
 						continue
 					}
 
@@ -429,15 +964,28 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 					}
 
 					graphqlField.Name = fieldName
+					b.describeField(graphqlField, realDefinition, method.Name)
 					// Create simple resolver that calls the getter method
 					methodFunc := method.Func
+					receiverType := methodFunc.Type().In(0)
+					boundInstance, isBound := b.rootInstances[realDefinition]
 					graphqlField.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
 						sourceVal := reflect.ValueOf(p.Source)
+						if isBound {
+							// Root objects have no source, use the registered instance
+							sourceVal = reflect.ValueOf(boundInstance)
+						}
 						if !sourceVal.IsValid() {
 							return nil, nil
 						}
 						// Ensure we have correct type for method call
-						if sourceVal.Type().Kind() != reflect.Ptr {
+						if sourceVal.Kind() == reflect.Ptr && receiverType.Kind() != reflect.Ptr {
+							// Method is on value receiver, dereference the source
+							if sourceVal.IsNil() {
+								return nil, nil
+							}
+							sourceVal = sourceVal.Elem()
+						} else if sourceVal.Type().Kind() != reflect.Ptr && receiverType.Kind() == reflect.Ptr {
 							// Method is on pointer receiver, need to get address
 							if sourceVal.CanAddr() {
 								sourceVal = sourceVal.Addr()
@@ -450,15 +998,22 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 						}
 						results := methodFunc.Call([]reflect.Value{sourceVal})
 						if len(results) > 0 {
-							return results[0].Interface(), nil
+							return valueInterface(results[0]), nil
 						}
 						return nil, nil
 					}
+					graphqlField.Resolve = b.wrapResolver(fieldName, graphqlField.Resolve)
+					guardIntRange(graphqlField, returnType)
+					unwrapNamedBasic(graphqlField, returnType)
 					fields[fieldName] = graphqlField
 				}
 			}
 		}
 
+		for _, name := range sortedFieldNames(fields) {
+			b.logf("gql: built field %s.%s: %s", b.objectTypeName(realDefinition), name, fields[name].Type)
+		}
+
 		// Store fields in cache for thunk-based placeholders
 		b.fieldsCache[realDefinition] = fields
 
@@ -468,23 +1023,11 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 			return &graphql.Field{Type: existingType}, nil
 		}
 
-		// Check if type has a custom GraphQL type name method
-		typeName := realDefinition.Name()
-		if method, ok := realDefinition.MethodByName("GraphQLTypeName"); ok {
-			if method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
-				// Call the method on a zero value to get the type name
-				zeroValue := reflect.New(realDefinition).Elem()
-				result := method.Func.Call([]reflect.Value{zeroValue})
-				if len(result) > 0 && result[0].Kind() == reflect.String {
-					typeName = result[0].String()
-				}
-			}
-		}
-
 		// Create the object with populated fields
 		graphqlType := graphql.NewObject(graphql.ObjectConfig{
-			Name:   typeName,
-			Fields: fields,
+			Name:        b.objectTypeName(realDefinition),
+			Description: b.descriptions[realDefinition.Name()],
+			Fields:      fields,
 		})
 
 		// Register the fully populated object
@@ -496,6 +1039,28 @@ func (b *SchemaBuilder) TypeAsGraphqlField(definition reflect.Type) (*graphql.Fi
 	}
 }
 
+// objectTypeName determines the GraphQL name of the object type for definition
+func (b *SchemaBuilder) objectTypeName(definition reflect.Type) string {
+	// Root objects may be renamed with WithRootName
+	if name, ok := b.rootTypeNames[definition]; ok {
+		return name
+	}
+
+	// Check if type has a custom GraphQL type name method
+	typeName := definition.Name()
+	if method, ok := definition.MethodByName("GraphQLTypeName"); ok {
+		if method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+			// Call the method on a zero value to get the type name
+			zeroValue := reflect.New(definition).Elem()
+			result := method.Func.Call([]reflect.Value{zeroValue})
+			if len(result) > 0 && result[0].Kind() == reflect.String {
+				typeName = result[0].String()
+			}
+		}
+	}
+	return typeName
+}
+
 func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*graphql.ArgumentConfig, error) {
 	// Check for custom type mappings first
 	if customType, ok := b.customTypes[definition]; ok {
@@ -504,6 +1069,19 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 		}, nil
 	}
 
+	// Types round-tripping through text are parsed from strings
+	if isTextScalarType(definition) {
+		return &graphql.ArgumentConfig{
+			Type: b.textScalar(definition),
+		}, nil
+	}
+
+	if b.isLongType(definition) {
+		return &graphql.ArgumentConfig{
+			Type: Long,
+		}, nil
+	}
+
 	switch definition.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return &graphql.ArgumentConfig{
@@ -525,6 +1103,14 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 		return &graphql.ArgumentConfig{
 			Type: graphql.Float,
 		}, nil
+	case reflect.Interface:
+		// Empty interfaces accept arbitrary JSON values
+		if definition.NumMethod() == 0 {
+			return &graphql.ArgumentConfig{
+				Type: JSON,
+			}, nil
+		}
+		return nil, fmt.Errorf("Unsupported type: %s", definition)
 	case reflect.Slice, reflect.Array:
 		elemConfig, err := b.TypeAsGraphqlArgumentConfig(definition.Elem())
 		if err != nil {
@@ -533,6 +1119,14 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 		return &graphql.ArgumentConfig{
 			Type: graphql.NewList(elemConfig.Type),
 		}, nil
+	case reflect.Map:
+		// Maps with string keys accept arbitrary JSON objects
+		if definition.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("Unsupported map key type: %s", definition.Key())
+		}
+		return &graphql.ArgumentConfig{
+			Type: JSON,
+		}, nil
 	case reflect.Ptr:
 		return b.TypeAsGraphqlArgumentConfig(definition.Elem())
 	case reflect.Struct:
@@ -558,6 +1152,7 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 		if typeName == "" {
 			typeName = definition.Name()
 		}
+		b.logf("gql: building input %s from %s", typeName, definition)
 
 		// If deduplication is enabled, check if a structurally identical type was already created
 		if b.allowSharedTypes {
@@ -571,30 +1166,9 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 			}
 
 			// Build and cache the fields
-			fields := graphql.InputObjectConfigFieldMap{}
-			for i := 0; i < definition.NumField(); i++ {
-				field := definition.Field(i)
-				fieldName, isNonNull, err := GetGqlTag(&field)
-				if err != nil {
-					return nil, err
-				}
-
-				if fieldName == "" || fieldName == "-" {
-					continue
-				}
-
-				fieldConfig, err := b.TypeAsGraphqlArgumentConfig(field.Type)
-				if err != nil {
-					return nil, err
-				}
-
-				if isNonNull {
-					fieldConfig.Type = graphql.NewNonNull(fieldConfig.Type)
-				}
-
-				fields[fieldName] = &graphql.InputObjectFieldConfig{
-					Type: fieldConfig.Type,
-				}
+			fields, err := b.inputObjectFields(definition)
+			if err != nil {
+				return nil, err
 			}
 
 			// Create the InputObject
@@ -603,6 +1177,8 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 				Fields: fields,
 			})
 
+			b.markOneOf(definition, inputObj)
+
 			// Cache by both Go type and structural hash
 			b.inputTypeRegistry[definition] = inputObj
 			b.hashToInputType[hash] = inputObj
@@ -614,30 +1190,9 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 		}
 
 		// Deduplication disabled - create a new InputObject without caching by hash
-		fields := graphql.InputObjectConfigFieldMap{}
-		for i := 0; i < definition.NumField(); i++ {
-			field := definition.Field(i)
-			fieldName, isNonNull, err := GetGqlTag(&field)
-			if err != nil {
-				return nil, err
-			}
-
-			if fieldName == "" || fieldName == "-" {
-				continue
-			}
-
-			fieldConfig, err := b.TypeAsGraphqlArgumentConfig(field.Type)
-			if err != nil {
-				return nil, err
-			}
-
-			if isNonNull {
-				fieldConfig.Type = graphql.NewNonNull(fieldConfig.Type)
-			}
-
-			fields[fieldName] = &graphql.InputObjectFieldConfig{
-				Type: fieldConfig.Type,
-			}
+		fields, err := b.inputObjectFields(definition)
+		if err != nil {
+			return nil, err
 		}
 
 		inputObj := graphql.NewInputObject(graphql.InputObjectConfig{
@@ -645,6 +1200,8 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 			Fields: fields,
 		})
 
+		b.markOneOf(definition, inputObj)
+
 		// Only cache by Go type, not by hash
 		b.inputTypeRegistry[definition] = inputObj
 
@@ -656,6 +1213,118 @@ func (b *SchemaBuilder) TypeAsGraphqlArgumentConfig(definition reflect.Type) (*g
 	}
 }
 
+// inputObjectFields builds the input field configs of the tagged fields of a struct
+func (b *SchemaBuilder) inputObjectFields(definition reflect.Type) (graphql.InputObjectConfigFieldMap, error) {
+	if isOneOfInput(definition) {
+		if err := checkOneOfFields(definition); err != nil {
+			return nil, err
+		}
+	}
+
+	defaults, hasDefaults := inputDefaults(definition)
+	if hasDefaults {
+		b.inputDefaultTypes[definition] = true
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	for i := 0; i < definition.NumField(); i++ {
+		field := definition.Field(i)
+
+		// Untagged embedded structs contribute their fields at the same level
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get(GqlTagKey) == "" {
+			embedded, err := b.inputObjectFields(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			for fieldName, fieldConfig := range embedded {
+				// Fields of the outer struct take precedence
+				if _, exists := fields[fieldName]; !exists {
+					fields[fieldName] = fieldConfig
+				}
+			}
+			continue
+		}
+
+		fieldName, fieldConfig, err := b.inputFieldConfig(&field)
+		if err != nil {
+			return nil, err
+		}
+		if fieldConfig != nil && fieldConfig.Description == "" {
+			fieldConfig.Description = b.descriptions[definition.Name()+"."+field.Name]
+		}
+		if fieldConfig != nil && hasDefaults {
+			if err := applyFieldDefault(fieldConfig, &field, defaults.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+		if fieldConfig != nil {
+			fields[fieldName] = fieldConfig
+		}
+	}
+	return fields, nil
+}
+
+// inputFieldConfig builds the input field config of a tagged struct field,
+// carrying the description and default value tag options. It returns a nil
+// config for fields without a valid tag.
+func (b *SchemaBuilder) inputFieldConfig(field *reflect.StructField) (string, *graphql.InputObjectFieldConfig, error) {
+	tag, err := ParseGqlTagFromField(field)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fieldName := b.exposedFieldName(field, tag.FieldName)
+	if fieldName == "" || fieldName == "-" {
+		return "", nil, nil
+	}
+
+	argConfig, err := b.TypeAsGraphqlArgumentConfig(field.Type)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if tag.IsNonNullItems() {
+		itemsType, err := nonNullItemsType(argConfig.Type)
+		if err != nil {
+			return "", nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		argConfig.Type = itemsType.(graphql.Input)
+	}
+
+	if tag.IsNonNull() {
+		argConfig.Type = graphql.NewNonNull(argConfig.Type)
+	}
+
+	fieldConfig := &graphql.InputObjectFieldConfig{Type: argConfig.Type}
+	fieldConfig.Description, _ = tag.Option("description")
+	if value, ok := tag.Option("default"); ok {
+		fieldConfig.DefaultValue, err = parseDefaultValue(value, field.Type)
+		if err != nil {
+			return "", nil, fmt.Errorf("Invalid default value for field %s: %w", field.Name, err)
+		}
+	}
+
+	return fieldName, fieldConfig, nil
+}
+
+// parseDefaultValue converts a default tag option to a value of the field's kind
+func parseDefaultValue(value string, t reflect.Type) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.Atoi(value)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	}
+	return value, nil
+}
+
 func (b *SchemaBuilder) populateGraphqlFieldArgs(graphqlField *graphql.Field, definition reflect.Type) error {
 	// Handle pointer types
 	if definition.Kind() == reflect.Ptr {
@@ -670,31 +1339,73 @@ func (b *SchemaBuilder) populateGraphqlFieldArgs(graphqlField *graphql.Field, de
 
 	// Iterate over struct fields directly
 	// This supports both named and anonymous structs
-	for i := 0; i < definition.NumField(); i++ {
-		field := definition.Field(i)
+	fields, err := b.inputObjectFields(definition)
+	if err != nil {
+		return err
+	}
 
-		fieldName, isNonNull, err := GetGqlTag(&field)
-		if err != nil {
-			return err
+	// Flatten the input fields into arguments keeping their metadata
+	for fieldName, fieldConfig := range fields {
+		graphqlField.Args[fieldName] = &graphql.ArgumentConfig{
+			Type:         fieldConfig.Type,
+			DefaultValue: fieldConfig.DefaultValue,
+			Description:  fieldConfig.Description,
 		}
+	}
 
-		// Skip fields without valid tags
-		if fieldName == "" || fieldName == "-" {
-			continue
-		}
+	return nil
+}
 
-		// Create argument config for the field
-		fieldArgConfig, err := b.TypeAsGraphqlArgumentConfig(field.Type)
-		if err != nil {
-			return err
-		}
+// resolverAsGraphqlField builds the field resolved by resolveInfo, typed
+// after its output and taking its input as arguments
+func (b *SchemaBuilder) resolverAsGraphqlField(fieldName string, resolveInfo *ResolveInfo) (*graphql.Field, error) {
+	if resolveInfo.OutputType().Kind() == reflect.Chan {
+		return b.channelAsGraphqlField(fieldName, resolveInfo)
+	}
 
-		if isNonNull {
-			fieldArgConfig.Type = graphql.NewNonNull(fieldArgConfig.Type)
-		}
+	graphqlField, err := b.TypeAsGraphqlField(resolveInfo.OutputType())
+	if err != nil {
+		return nil, err
+	}
 
-		graphqlField.Args[fieldName] = fieldArgConfig
+	graphqlField.Name = fieldName
+	if b.nullableResolvers {
+		graphqlField.Type = nullableType(graphqlField.Type)
 	}
+	graphqlField.Resolve = b.wrapResolver(fieldName, resolveInfo.Resolve)
+	guardIntRange(graphqlField, resolveInfo.OutputType())
+	unwrapNamedBasic(graphqlField, resolveInfo.OutputType())
+	guardNonNull(graphqlField)
+	if err := b.populateResolverArgs(graphqlField, resolveInfo); err != nil {
+		return nil, err
+	}
+	return graphqlField, nil
+}
 
+// populateResolverArgs sets the field arguments from the resolver's input
+func (b *SchemaBuilder) populateResolverArgs(graphqlField *graphql.Field, resolveInfo *ResolveInfo) error {
+	if resolveInfo.ScalarInputName != "" {
+		return b.populateGraphqlFieldScalarArg(graphqlField, resolveInfo)
+	}
+	if resolveInfo.Input != nil {
+		return b.populateGraphqlFieldArgs(graphqlField, resolveInfo.Input.Type)
+	}
+	return nil
+}
+
+func (b *SchemaBuilder) populateGraphqlFieldScalarArg(graphqlField *graphql.Field, resolveInfo *ResolveInfo) error {
+	argConfig, err := b.TypeAsGraphqlArgumentConfig(resolveInfo.Input.Type)
+	if err != nil {
+		return err
+	}
+
+	// Value inputs are required, pointer inputs are optional
+	if !resolveInfo.Input.IsPtr {
+		argConfig.Type = graphql.NewNonNull(argConfig.Type)
+	}
+
+	graphqlField.Args = graphql.FieldConfigArgument{
+		resolveInfo.ScalarInputName: argConfig,
+	}
 	return nil
 }
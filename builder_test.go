@@ -3,10 +3,15 @@ package gql
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
 )
 
 type Tagged struct {
@@ -169,3 +174,1123 @@ func TestResolve(t *testing.T) {
 		}
 	}
 }
+
+type ScalarInputHost struct{}
+
+func (h *ScalarInputHost) GetUser(ctx context.Context, id string) (string, error) {
+	return "user " + id, nil
+}
+
+func (h *ScalarInputHost) Square(n *int) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	return *n * *n, nil
+}
+
+func TestScalarInputs(t *testing.T) {
+	_, err := NewSchemaBuilder().WithQuery(&ScalarInputHost{}).BuildSchema()
+	if err == nil {
+		t.Fatalf("expected error without scalar inputs enabled")
+	}
+
+	schema, err := NewSchemaBuilder().WithScalarInputs(true).WithQuery(&ScalarInputHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ getUser(input: "42") square(input: 3) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"getUser": "user 42", "square": 9}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ square }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected = map[string]interface{}{"square": 0}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type NullableHost struct {
+	count *int
+}
+
+func (h *NullableHost) Count() *int {
+	return h.count
+}
+
+func (h *NullableHost) CountOrError() (*int, error) {
+	return h.count, nil
+}
+
+func TestNullableScalarOutputs(t *testing.T) {
+	value := 3
+
+	cases := []struct {
+		host     *NullableHost
+		expected map[string]interface{}
+	}{
+		{
+			host:     &NullableHost{},
+			expected: map[string]interface{}{"count": nil, "countOrError": nil},
+		},
+		{
+			host:     &NullableHost{count: &value},
+			expected: map[string]interface{}{"count": 3, "countOrError": 3},
+		},
+	}
+
+	for _, c := range cases {
+		schema, err := NewSchemaBuilder().WithQuery(c.host).BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		fieldType := schema.QueryType().Fields()["count"].Type
+		if _, ok := fieldType.(*graphql.NonNull); ok {
+			t.Fatalf("expected nullable Int, got %v", fieldType)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: `{ count countOrError }`,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		if !reflect.DeepEqual(result.Data, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, result.Data)
+		}
+	}
+}
+
+type AuthUser struct {
+	Name string
+}
+
+type authUserKey struct{}
+
+type AuthHost struct{}
+
+func (h *AuthHost) Me(user *AuthUser) (string, error) {
+	if user == nil {
+		return "anonymous", nil
+	}
+	return user.Name, nil
+}
+
+func (h *AuthHost) Greet(ctx context.Context, user *AuthUser, input Tagged) (string, error) {
+	return input.Field + " " + user.Name, nil
+}
+
+func (h *AuthHost) Describe(ctx context.Context, info graphql.ResolveInfo, user *AuthUser, input Tagged) (string, error) {
+	return info.FieldName + " " + input.Field + " " + user.Name, nil
+}
+
+func TestContextProvider(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithContextProvider(reflect.TypeOf(&AuthUser{}), func(ctx context.Context) (interface{}, error) {
+			user, _ := ctx.Value(authUserKey{}).(*AuthUser)
+			if user == nil {
+				return nil, nil
+			}
+			return user, nil
+		}).
+		WithQuery(&AuthHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := []struct {
+		ctx      context.Context
+		query    string
+		expected map[string]interface{}
+	}{
+		{
+			ctx:      context.WithValue(context.Background(), authUserKey{}, &AuthUser{Name: "john"}),
+			query:    `{ me greet(field: "hello") describe(field: "for") }`,
+			expected: map[string]interface{}{"me": "john", "greet": "hello john", "describe": "describe for john"},
+		},
+		{
+			ctx:      context.Background(),
+			query:    `{ me }`,
+			expected: map[string]interface{}{"me": "anonymous"},
+		},
+	}
+
+	for _, c := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: c.query,
+			Context:       c.ctx,
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		if !reflect.DeepEqual(result.Data, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, result.Data)
+		}
+	}
+}
+
+type ListUser struct {
+	Name string `gql:"name"`
+}
+
+type ListHost struct {
+	Users         []ListUser  `gql:"users"`
+	OptionalUsers *[]ListUser `gql:"optionalUsers"`
+	TaggedUsers   []ListUser  `gql:"taggedUsers,nullable"`
+}
+
+func TestNonNullLists(t *testing.T) {
+	cases := []struct {
+		nonNullLists bool
+		expected     map[string]string
+	}{
+		{
+			nonNullLists: false,
+			expected:     map[string]string{"users": "[ListUser]", "optionalUsers": "[ListUser]", "taggedUsers": "[ListUser]"},
+		},
+		{
+			nonNullLists: true,
+			expected:     map[string]string{"users": "[ListUser]!", "optionalUsers": "[ListUser]", "taggedUsers": "[ListUser]"},
+		},
+	}
+
+	for _, c := range cases {
+		schema, err := NewSchemaBuilder().WithNonNullLists(c.nonNullLists).WithQuery(&ListHost{}).BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		fields := schema.QueryType().Fields()
+		for name, expected := range c.expected {
+			if actual := fields[name].Type.String(); actual != expected {
+				t.Errorf("expected %s to be %s, got %s", name, expected, actual)
+			}
+		}
+	}
+}
+
+type AddressInput struct {
+	Street string `gql:"street,nonNull"`
+	City   string `gql:"city"`
+}
+
+type CustomerInput struct {
+	Name    string        `gql:"name,nonNull"`
+	Address AddressInput  `gql:"address,nonNull"`
+	Billing *AddressInput `gql:"billing"`
+}
+
+type NestedInputHost struct{}
+
+func (h *NestedInputHost) CreateCustomer(input CustomerInput) (string, error) {
+	result := input.Name + " at " + input.Address.Street + ", " + input.Address.City
+	if input.Billing != nil {
+		result += " billed at " + input.Billing.Street
+	}
+	return result, nil
+}
+
+func TestNestedInputObjects(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&NestedInputHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := []struct {
+		query     string
+		variables map[string]interface{}
+		expected  string
+	}{
+		{
+			query:    `{ createCustomer(name: "john", address: { street: "Main St", city: "Springfield" }) }`,
+			expected: "john at Main St, Springfield",
+		},
+		{
+			query: `query($billing: AddressInput) { createCustomer(name: "john", address: { street: "Main St" }, billing: $billing) }`,
+			variables: map[string]interface{}{
+				"billing": map[string]interface{}{"street": "Elm St"},
+			},
+			expected: "john at Main St,  billed at Elm St",
+		},
+	}
+
+	for _, c := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:         *schema,
+			RequestString:  c.query,
+			VariableValues: c.variables,
+			Context:        context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		expected := map[string]interface{}{"createCustomer": c.expected}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("expected %v, got %v", expected, result.Data)
+		}
+	}
+}
+
+func TestWithRootName(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithRootName(Query, "Query").
+		WithRootName(Mutation, "RootMutation").
+		WithQuery(&HandlerQuery{}).
+		WithMutation(&NamingHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if name := schema.QueryType().Name(); name != "Query" {
+		t.Fatalf("expected query root Query, got %s", name)
+	}
+
+	sdl := PrintSchema(schema)
+	for _, expected := range []string{"  query: Query\n", "  mutation: RootMutation\n", "type RootMutation {"} {
+		if !strings.Contains(sdl, expected) {
+			t.Errorf("expected SDL to contain %q, got:\n%s", expected, sdl)
+		}
+	}
+}
+
+type FoundInput struct {
+	Name string `gql:"name,nonNull"`
+}
+
+type FoundHost struct{}
+
+func (h *FoundHost) FindUser(input FoundInput) (*ListUser, bool) {
+	if input.Name != "alice" {
+		return nil, false
+	}
+	return &ListUser{Name: input.Name}, true
+}
+
+func (h *FoundHost) FindAge(input FoundInput) (int, bool) {
+	return 30, input.Name == "alice"
+}
+
+func TestFoundResolvers(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&FoundHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := []struct {
+		query    string
+		expected map[string]interface{}
+	}{
+		{
+			query: `{ findUser(name: "alice") { name } findAge(name: "alice") }`,
+			expected: map[string]interface{}{
+				"findUser": map[string]interface{}{"name": "alice"},
+				"findAge":  30,
+			},
+		},
+		{
+			query:    `{ findUser(name: "bob") { name } findAge(name: "bob") }`,
+			expected: map[string]interface{}{"findUser": nil, "findAge": nil},
+		},
+	}
+
+	for _, c := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: c.query,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		if !reflect.DeepEqual(result.Data, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, result.Data)
+		}
+	}
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	b := NewSchemaBuilder().WithQuery(&NamedSliceHost{})
+	if _, err := b.BuildSchema(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	types := b.RegisteredTypes()
+	for goType, name := range map[reflect.Type]string{
+		reflect.TypeOf(SliceUser{}):   "SliceUser",
+		reflect.TypeOf(TeamMembers{}): "TeamMembers",
+	} {
+		graphqlType, ok := types[goType]
+		if !ok {
+			t.Fatalf("expected %s to be registered", goType)
+		}
+		if graphqlType.Name() != name {
+			t.Fatalf("expected %s, got %s", name, graphqlType.Name())
+		}
+	}
+
+	delete(types, reflect.TypeOf(SliceUser{}))
+	if _, ok := b.RegisteredTypes()[reflect.TypeOf(SliceUser{})]; !ok {
+		t.Fatalf("expected registry to be unaffected by changes to the returned map")
+	}
+}
+
+type PageInput struct {
+	Limit  int    `gql:"limit,default=10,description=Maximum number of items"`
+	Order  string `gql:"order,default=asc"`
+	Filter string `gql:"filter"`
+}
+
+type PageHost struct{}
+
+func (h *PageHost) Page(input PageInput) (string, error) {
+	return fmt.Sprintf("%d %s", input.Limit, input.Order), nil
+}
+
+func TestArgumentDefaultsAndDescriptions(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&PageHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	args := map[string]*graphql.Argument{}
+	for _, arg := range schema.QueryType().Fields()["page"].Args {
+		args[arg.Name()] = arg
+	}
+	if args["limit"].DefaultValue != 10 || args["limit"].Description() != "Maximum number of items" {
+		t.Fatalf("expected limit default and description, got %v %q", args["limit"].DefaultValue, args["limit"].Description())
+	}
+	if args["order"].DefaultValue != "asc" {
+		t.Fatalf("expected order default asc, got %v", args["order"].DefaultValue)
+	}
+	if args["filter"].DefaultValue != nil {
+		t.Fatalf("expected no filter default, got %v", args["filter"].DefaultValue)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ defaults: page overridden: page(limit: 5, order: "desc") }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"defaults": "10 asc", "overridden": "5 desc"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type PointerProfile struct {
+	Bio string `gql:"bio"`
+}
+
+type PointerUser struct {
+	Name    string          `gql:"name,nonNull"`
+	Profile *PointerProfile `gql:"profile,nonNull"`
+}
+
+type PointerHost struct{}
+
+func (h *PointerHost) User() (*PointerUser, error) {
+	return &PointerUser{Name: "alice"}, nil
+}
+
+func TestNullablePointers(t *testing.T) {
+	cases := []struct {
+		nullablePointers bool
+		profileType      string
+		expectError      bool
+	}{
+		{nullablePointers: false, profileType: "PointerProfile!", expectError: true},
+		{nullablePointers: true, profileType: "PointerProfile"},
+	}
+
+	for _, c := range cases {
+		schema, err := NewSchemaBuilder().WithNullablePointers(c.nullablePointers).WithQuery(&PointerHost{}).BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		fields := schema.Type("PointerUser").(*graphql.Object).Fields()
+		if fieldType := fields["profile"].Type.String(); fieldType != c.profileType {
+			t.Fatalf("expected %s, got %s", c.profileType, fieldType)
+		}
+		if fieldType := fields["name"].Type.String(); fieldType != "String!" {
+			t.Fatalf("expected String!, got %s", fieldType)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: `{ user { name profile { bio } } }`,
+			Context:       context.Background(),
+		})
+		if c.expectError {
+			if result.Errors == nil {
+				t.Fatalf("expected non-null error for nil pointer field")
+			}
+			continue
+		}
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		expected := map[string]interface{}{
+			"user": map[string]interface{}{"name": "alice", "profile": nil},
+		}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("expected %v, got %v", expected, result.Data)
+		}
+	}
+}
+
+type EchoAddress struct {
+	City string `gql:"city"`
+}
+
+type EchoUser struct {
+	Name    string      `gql:"name"`
+	Address EchoAddress `gql:"address"`
+}
+
+type CreateEchoUserInput struct {
+	User EchoUser `gql:"user,nonNull"`
+}
+
+type EchoMutation struct{}
+
+func (m *EchoMutation) CreateUser(input CreateEchoUserInput) (*EchoUser, error) {
+	return &input.User, nil
+}
+
+func TestInputAndOutputTypeNames(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithQuery(&FoundHost{}).
+		WithMutation(&EchoMutation{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for name, expected := range map[string]interface{}{
+		"EchoUser":         &graphql.Object{},
+		"EchoAddress":      &graphql.Object{},
+		"EchoUserInput":    &graphql.InputObject{},
+		"EchoAddressInput": &graphql.InputObject{},
+	} {
+		if reflect.TypeOf(schema.Type(name)) != reflect.TypeOf(expected) {
+			t.Fatalf("expected %s to be a %T, got %T", name, expected, schema.Type(name))
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `mutation { createUser(user: {name: "alice", address: {city: "Paris"}}) { name address { city } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"createUser": map[string]interface{}{
+			"name":    "alice",
+			"address": map[string]interface{}{"city": "Paris"},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type NestedPost struct {
+	Title string `gql:"title"`
+}
+
+type NestedUser struct {
+	Name  string `gql:"name"`
+	posts []*NestedPost
+}
+
+type LimitInput struct {
+	Limit int `gql:"limit"`
+}
+
+func (u *NestedUser) Posts(input LimitInput) ([]*NestedPost, error) {
+	if input.Limit < len(u.posts) {
+		return u.posts[:input.Limit], nil
+	}
+	return u.posts, nil
+}
+
+type UserNameInput struct {
+	Name string `gql:"name,nonNull"`
+}
+
+type NestedArgsHost struct{}
+
+func (h *NestedArgsHost) User(input UserNameInput) (*NestedUser, error) {
+	return &NestedUser{
+		Name:  input.Name,
+		posts: []*NestedPost{{Title: "first"}, {Title: "second"}, {Title: "third"}},
+	}, nil
+}
+
+func TestNestedResolverArguments(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&NestedArgsHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	postsArgs := schema.Type("NestedUser").(*graphql.Object).Fields()["posts"].Args
+	if len(postsArgs) != 1 || postsArgs[0].Name() != "limit" {
+		t.Fatalf("expected posts to only take limit, got %v", postsArgs)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ user(name: "alice") { name posts(limit: 2) { title } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name": "alice",
+			"posts": []interface{}{
+				map[string]interface{}{"title": "first"},
+				map[string]interface{}{"title": "second"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestWithTypesAndDirectives(t *testing.T) {
+	extra := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Extra",
+		Fields: graphql.Fields{
+			"value": &graphql.Field{Type: graphql.String},
+		},
+	})
+	auth := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:      "auth",
+		Locations: []string{graphql.DirectiveLocationField},
+	})
+
+	schemaConfig, err := NewSchemaBuilder().
+		WithQuery(&HandlerQuery{}).
+		WithTypes(extra).
+		WithDirectives(auth).
+		BuildSchemaConfig()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	schema, err := graphql.NewSchema(*schemaConfig)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if schema.Type("Extra") != extra {
+		t.Fatalf("expected Extra type in schema, got %v", schema.Type("Extra"))
+	}
+	for _, name := range []string{"auth", "include", "skip", "deprecated"} {
+		if schema.Directive(name) == nil {
+			t.Fatalf("expected %s directive in schema", name)
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hello @auth }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+}
+
+type ItemsHost struct {
+	Tags   []string `gql:"tags,nonNull"`
+	Labels []string `gql:"labels,nonNullItems"`
+	Codes  []string `gql:"codes,nonNull,nonNullItems"`
+}
+
+type ItemsInput struct {
+	IDs []string `gql:"ids,nonNullItems"`
+}
+
+func (h *ItemsHost) Count(input ItemsInput) (int, error) {
+	return len(input.IDs), nil
+}
+
+func TestNonNullItems(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ItemsHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.QueryType().Fields()
+	expected := map[string]string{"tags": "[String]!", "labels": "[String!]", "codes": "[String!]!"}
+	for name, fieldType := range expected {
+		if actual := fields[name].Type.String(); actual != fieldType {
+			t.Errorf("expected %s to be %s, got %s", name, fieldType, actual)
+		}
+	}
+	if argType := fields["count"].Args[0].Type.String(); argType != "[String!]" {
+		t.Errorf("expected ids to be [String!], got %s", argType)
+	}
+
+	type NotAList struct {
+		Name string `gql:"name,nonNullItems"`
+	}
+	type InvalidItemsHost struct {
+		Value NotAList `gql:"value"`
+	}
+	if _, err := NewSchemaBuilder().WithQuery(&InvalidItemsHost{}).BuildSchema(); err == nil {
+		t.Fatalf("expected error for nonNullItems on a non-list field")
+	}
+}
+
+type GetterUser struct {
+	FirstName string `gql:"firstName"`
+	LastName  string `gql:"lastName"`
+}
+
+func (u *GetterUser) DisplayName() string {
+	return u.FirstName + " " + u.LastName
+}
+
+func (u GetterUser) Initials() string {
+	return u.FirstName[:1] + u.LastName[:1]
+}
+
+type GetterHost struct{}
+
+func (h *GetterHost) User() (*GetterUser, error) {
+	return &GetterUser{FirstName: "Ada", LastName: "Lovelace"}, nil
+}
+
+func TestGetterVirtualFields(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&GetterHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ user { firstName displayName initials } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"user": map[string]interface{}{"firstName": "Ada", "displayName": "Ada Lovelace", "initials": "AL"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type ExposedAccount struct {
+	ID       string
+	Email    string
+	Password string `gql:"-"`
+	Verified bool   `gql:"isVerified"`
+	internal string
+}
+
+type ExposedAccountInput struct {
+	Email string
+	Token string `gql:"-"`
+}
+
+type ExposedHost struct{}
+
+func (h *ExposedHost) Account(input ExposedAccountInput) (*ExposedAccount, error) {
+	return &ExposedAccount{ID: "a1", Email: input.Email, Password: "secret", Verified: true, internal: "x"}, nil
+}
+
+func TestWithExposeAllFields(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ExposedHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := schema.Type("ExposedAccount").(*graphql.Object).Fields()["email"]; ok {
+		t.Fatalf("expected untagged fields to be hidden without WithExposeAllFields")
+	}
+
+	schema, err = NewSchemaBuilder().WithExposeAllFields(true).WithQuery(&ExposedHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	account := schema.Type("ExposedAccount").(*graphql.Object).Fields()
+	for _, name := range []string{"id", "email", "isVerified"} {
+		if _, ok := account[name]; !ok {
+			t.Fatalf("expected field %s, got %v", name, account)
+		}
+	}
+	for _, name := range []string{"password", "internal"} {
+		if _, ok := account[name]; ok {
+			t.Fatalf("expected field %s to be hidden", name)
+		}
+	}
+	args := schema.QueryType().Fields()["account"].Args
+	if len(args) != 1 || args[0].Name() != "email" {
+		t.Fatalf("expected only the email argument, got %v", args)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ account(email: "ada@example.com") { id email isVerified } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"account": map[string]interface{}{"id": "a1", "email": "ada@example.com", "isVerified": true},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestEmptyBuilder(t *testing.T) {
+	_, err := NewSchemaBuilder().BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "no root fields defined") {
+		t.Fatalf("expected no root fields error, got %v", err)
+	}
+}
+
+type OverriddenEvent struct {
+	Created time.Time `gql:"createdAt"`
+	Name    string    `gql:"name"`
+}
+
+// CreatedAt overrides the createdAt field with a formatted string
+func (e *OverriddenEvent) CreatedAt(ctx context.Context) (string, error) {
+	return e.Created.Format("2006-01-02"), nil
+}
+
+type OverriddenHost struct{}
+
+func (h *OverriddenHost) Event() (*OverriddenEvent, error) {
+	return &OverriddenEvent{Created: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), Name: "launch"}, nil
+}
+
+func TestResolverMethodOverridesField(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&OverriddenHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.Type("OverriddenEvent").(*graphql.Object).Fields()
+	if fieldType := fields["createdAt"].Type.String(); fieldType != "String" {
+		t.Fatalf("expected the method's String type, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ event { name createdAt } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"event": map[string]interface{}{"name": "launch", "createdAt": "2024-03-01"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type GreeterAPI interface {
+	Greet(input UserNameInput) (string, error)
+}
+
+type realGreeter struct{}
+
+func (g *realGreeter) Greet(input UserNameInput) (string, error) {
+	return "hello " + input.Name, nil
+}
+
+type mockGreeter struct{}
+
+func (g mockGreeter) Greet(input UserNameInput) (string, error) {
+	return "mock " + input.Name, nil
+}
+
+func TestInterfaceQuery(t *testing.T) {
+	for greeter, expected := range map[GreeterAPI]string{
+		&realGreeter{}: "hello ada",
+		mockGreeter{}:  "mock ada",
+	} {
+		schema, err := NewSchemaBuilder().WithQuery(greeter).BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: `{ greet(name: "ada") }`,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+		if greeting := result.Data.(map[string]interface{})["greet"]; greeting != expected {
+			t.Fatalf("expected %s, got %v", expected, greeting)
+		}
+	}
+}
+
+type Tracer interface {
+	Span(name string) string
+}
+
+type prefixTracer string
+
+func (t prefixTracer) Span(name string) string {
+	return string(t) + "/" + name
+}
+
+type loggerKey struct{}
+
+type AmbientHost struct{}
+
+func (h *AmbientHost) Trace(ctx context.Context, logger *log.Logger, input UserNameInput, tracer Tracer) (string, error) {
+	logger.Printf("tracing %s", input.Name)
+	return tracer.Span(input.Name), nil
+}
+
+func TestMultipleContextProviders(t *testing.T) {
+	var logs strings.Builder
+	schema, err := NewSchemaBuilder().
+		WithContextProvider(reflect.TypeOf(&log.Logger{}), func(ctx context.Context) (interface{}, error) {
+			return ctx.Value(loggerKey{}), nil
+		}).
+		WithContextProvider(reflect.TypeOf((*Tracer)(nil)).Elem(), func(ctx context.Context) (interface{}, error) {
+			return prefixTracer("req-1"), nil
+		}).
+		WithQuery(&AmbientHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ trace(name: "ada") }`,
+		Context:       context.WithValue(context.Background(), loggerKey{}, log.New(&logs, "", 0)),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	if trace := result.Data.(map[string]interface{})["trace"]; trace != "req-1/ada" {
+		t.Fatalf("expected req-1/ada, got %v", trace)
+	}
+	if logs.String() != "tracing ada\n" {
+		t.Fatalf("expected the logger to be used, got %q", logs.String())
+	}
+}
+
+type ElementsHost struct {
+	Names    []string  `gql:"names"`
+	Nicks    []*string `gql:"nicks"`
+	Required []*string `gql:"required,nonNullItems"`
+}
+
+func TestNonNullElements(t *testing.T) {
+	nick := "ada"
+	host := &ElementsHost{Names: []string{"a"}, Nicks: []*string{&nick, nil}, Required: []*string{&nick}}
+	schema, err := NewSchemaBuilder().WithNonNullElements(true).WithQuery(host).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.QueryType().Fields()
+	expected := map[string]string{"names": "[String!]", "nicks": "[String]", "required": "[String!]"}
+	for name, fieldType := range expected {
+		if actual := fields[name].Type.String(); actual != fieldType {
+			t.Errorf("expected %s to be %s, got %s", name, fieldType, actual)
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ names nicks }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	data := map[string]interface{}{"names": []interface{}{"a"}, "nicks": []interface{}{"ada", nil}}
+	if !reflect.DeepEqual(result.Data, data) {
+		t.Fatalf("expected %v, got %v", data, result.Data)
+	}
+
+	schema, err = NewSchemaBuilder().WithNonNullElements(true).WithNonNullLists(true).WithQuery(host).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if actual := schema.QueryType().Fields()["names"].Type.String(); actual != "[String!]!" {
+		t.Errorf("expected [String!]!, got %s", actual)
+	}
+}
+
+type Article struct {
+	Title string `gql:"title"`
+}
+
+type Writer struct {
+	Name     string `gql:"name"`
+	articles []*Article
+}
+
+func (w *Writer) RecentArticles(ctx context.Context) ([]*Article, error) {
+	return w.articles, nil
+}
+
+type WriterHost struct{}
+
+func (h *WriterHost) Writer() (*Writer, error) {
+	return &Writer{Name: "ada", articles: []*Article{{Title: "notes"}, {Title: "engines"}}}, nil
+}
+
+func TestComputedListField(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&WriterHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.Type("Writer").(*graphql.Object).Fields()
+	if fieldType := fields["recentArticles"].Type.String(); fieldType != "[Article]" {
+		t.Fatalf("expected [Article], got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ writer { name recentArticles { title } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"writer": map[string]interface{}{
+			"name": "ada",
+			"recentArticles": []interface{}{
+				map[string]interface{}{"title": "notes"},
+				map[string]interface{}{"title": "engines"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+// fieldTracer is an extension recording the fields it sees resolved
+type fieldTracer struct {
+	fields []string
+}
+
+func (e *fieldTracer) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return ctx
+}
+
+func (e *fieldTracer) Name() string {
+	return "fieldTracer"
+}
+
+func (e *fieldTracer) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(err error) {}
+}
+
+func (e *fieldTracer) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func(errs []gqlerrors.FormattedError) {}
+}
+
+func (e *fieldTracer) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(result *graphql.Result) {}
+}
+
+func (e *fieldTracer) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	e.fields = append(e.fields, info.FieldName)
+	return ctx, func(v interface{}, err error) {}
+}
+
+func (e *fieldTracer) HasResult() bool {
+	return true
+}
+
+func (e *fieldTracer) GetResult(ctx context.Context) interface{} {
+	return len(e.fields)
+}
+
+func TestWithExtensions(t *testing.T) {
+	tracer := &fieldTracer{}
+	schema, err := NewSchemaBuilder().WithExtensions(tracer).WithQuery(&WriterHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ writer { name } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	if expected := []string{"writer", "name"}; !reflect.DeepEqual(tracer.fields, expected) {
+		t.Fatalf("expected %v, got %v", expected, tracer.fields)
+	}
+	if count := result.Extensions["fieldTracer"]; count != 2 {
+		t.Fatalf("expected extension result 2, got %v", count)
+	}
+}
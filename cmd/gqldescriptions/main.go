@@ -0,0 +1,202 @@
+// Command gqldescriptions generates a map of GraphQL descriptions from the doc
+// comments of the types, struct fields and methods of a Go package, to be
+// passed to gql.SchemaBuilder.WithDescriptions. It is meant to be run with
+// go:generate:
+//
+//	//go:generate go run github.com/kadirpekel/gql/cmd/gqldescriptions -output descriptions_gen.go
+//
+// Descriptions are keyed by bare type names, so the map of a schema whose
+// types span several packages is generated from all of them with -include,
+// and type names declared twice are reported as errors.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package to read")
+	include := flag.String("include", "", "comma separated directories of other packages whose types the schema uses")
+	output := flag.String("output", "descriptions_gen.go", "file to write, relative to dir")
+	name := flag.String("var", "Descriptions", "name of the generated map variable")
+	flag.Parse()
+
+	dirs := []string{*dir}
+	if *include != "" {
+		dirs = append(dirs, strings.Split(*include, ",")...)
+	}
+
+	pkgName, descriptions, err := readDescriptions(dirs, *output)
+	if err != nil {
+		log.Fatalf("gqldescriptions: %v", err)
+	}
+
+	source, err := render(pkgName, *name, descriptions)
+	if err != nil {
+		log.Fatalf("gqldescriptions: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), source, 0o644); err != nil {
+		log.Fatalf("gqldescriptions: %v", err)
+	}
+}
+
+// collector gathers doc comments keyed by bare type names, as looked up by
+// WithDescriptions, along with the position each key was declared at so that
+// types of the same name in several packages are reported instead of
+// overwriting each other
+type collector struct {
+	fset         *token.FileSet
+	descriptions map[string]string
+	positions    map[string]token.Pos
+	duplicates   []string
+}
+
+// readDescriptions collects the doc comments of the packages in dirs, keyed
+// by type name and by type and field or method name, skipping tests, output
+// and files excluded from the current build. The generated file belongs to the package of the first directory.
+func readDescriptions(dirs []string, output string) (string, map[string]string, error) {
+	c := &collector{
+		fset:         token.NewFileSet(),
+		descriptions: map[string]string{},
+		positions:    map[string]token.Pos{},
+	}
+
+	var pkgName string
+	for i, dir := range dirs {
+		pkgs, err := parser.ParseDir(c.fset, dir, func(info os.FileInfo) bool {
+			if strings.HasSuffix(info.Name(), "_test.go") || info.Name() == filepath.Base(output) {
+				return false
+			}
+			// Only files of the current build, as go generate runs for, declare
+			// the package's types
+			match, err := build.Default.MatchFile(dir, info.Name())
+			return err == nil && match
+		}, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(pkgs) != 1 {
+			return "", nil, fmt.Errorf("expected one package in %s, got %d", dir, len(pkgs))
+		}
+
+		for name, pkg := range pkgs {
+			if i == 0 {
+				pkgName = name
+			}
+			// Walk files in order so that duplicates are reported deterministically
+			files := make([]string, 0, len(pkg.Files))
+			for filename := range pkg.Files {
+				files = append(files, filename)
+			}
+			sort.Strings(files)
+			for _, filename := range files {
+				for _, decl := range pkg.Files[filename].Decls {
+					switch decl := decl.(type) {
+					case *ast.GenDecl:
+						c.collectTypes(decl)
+					case *ast.FuncDecl:
+						c.collectMethod(decl)
+					}
+				}
+			}
+		}
+	}
+
+	if len(c.duplicates) > 0 {
+		return "", nil, fmt.Errorf("descriptions are keyed by bare type names, duplicate keys:\n\t%s", strings.Join(c.duplicates, "\n\t"))
+	}
+	return pkgName, c.descriptions, nil
+}
+
+func (c *collector) collectTypes(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		// Single type declarations carry their doc on the declaration
+		doc := typeSpec.Doc
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+		c.add(typeSpec.Name.Name, typeSpec.Name.Pos(), doc)
+
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+		for _, field := range structType.Fields.List {
+			doc := field.Doc
+			if doc == nil {
+				doc = field.Comment
+			}
+			for _, name := range field.Names {
+				c.add(typeSpec.Name.Name+"."+name.Name, name.Pos(), doc)
+			}
+		}
+	}
+}
+
+func (c *collector) collectMethod(decl *ast.FuncDecl) {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return
+	}
+
+	receiver := decl.Recv.List[0].Type
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		receiver = star.X
+	}
+	if ident, ok := receiver.(*ast.Ident); ok {
+		c.add(ident.Name+"."+decl.Name.Name, decl.Name.Pos(), decl.Doc)
+	}
+}
+
+// add records the doc of key declared at pos. Keys are checked for
+// duplicates whether or not they are documented, since an undocumented
+// type would otherwise silently take the description of its namesake.
+func (c *collector) add(key string, pos token.Pos, doc *ast.CommentGroup) {
+	if previous, ok := c.positions[key]; ok {
+		c.duplicates = append(c.duplicates, fmt.Sprintf("%s declared at %s and %s", key, c.fset.Position(previous), c.fset.Position(pos)))
+		return
+	}
+	c.positions[key] = pos
+
+	if doc == nil {
+		return
+	}
+	if text := strings.TrimSpace(doc.Text()); text != "" {
+		c.descriptions[key] = text
+	}
+}
+
+func render(pkgName string, name string, descriptions map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(descriptions))
+	for key := range descriptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gqldescriptions. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "// %s holds the doc comments of the package for gql.SchemaBuilder.WithDescriptions\n", name)
+	fmt.Fprintf(&buf, "var %s = map[string]string{\n", name)
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", key, descriptions[key])
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return format.Source(buf.Bytes())
+}
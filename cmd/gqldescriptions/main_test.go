@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestReadDescriptions(t *testing.T) {
+	pkgName, descriptions, err := readDescriptions([]string{"testdata/store"}, "descriptions_gen.go")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pkgName != "store" {
+		t.Errorf("expected package store, got %s", pkgName)
+	}
+
+	expected := map[string]string{
+		"User":        "User is a customer of the store",
+		"User.Name":   "Name is the display name",
+		"User.Email":  "Email is the contact address",
+		"User.Orders": "Orders lists the orders placed by the user",
+		"Order":       "Order is a purchase",
+	}
+	if !reflect.DeepEqual(descriptions, expected) {
+		t.Errorf("expected %v, got %v", expected, descriptions)
+	}
+
+	source, err := render(pkgName, "Descriptions", descriptions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(string(source), `"User.Orders": "Orders lists the orders placed by the user",`) {
+		t.Errorf("expected the rendered map to hold User.Orders, got %s", source)
+	}
+}
+
+func TestReadDescriptionsDuplicateKeys(t *testing.T) {
+	_, _, err := readDescriptions([]string{"testdata/store", "testdata/accounts"}, "descriptions_gen.go")
+	if err == nil || !strings.Contains(err.Error(), "User declared at testdata/store/store.go:4:6 and testdata/accounts/accounts.go:4:6") {
+		t.Fatalf("expected a duplicate User error, got %v", err)
+	}
+
+}
+
+func TestReadDescriptionsBuildConstraints(t *testing.T) {
+	// Only the variant of the current build is read
+	_, descriptions, err := readDescriptions([]string{"testdata/platform"}, "descriptions_gen.go")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := map[string]string{"Platform": "Platform names an operating system"}
+	switch runtime.GOOS {
+	case "linux":
+		expected["Device"] = "Device is a block device"
+	case "windows":
+		expected["Device"] = "Device is a volume"
+	}
+	if !reflect.DeepEqual(descriptions, expected) {
+		t.Errorf("expected %v, got %v", expected, descriptions)
+	}
+}
@@ -0,0 +1,7 @@
+package accounts
+
+// User is an account holder
+type User struct {
+	// Login is the account name
+	Login string
+}
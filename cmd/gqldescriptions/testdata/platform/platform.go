@@ -0,0 +1,4 @@
+package platform
+
+// Platform names an operating system
+type Platform string
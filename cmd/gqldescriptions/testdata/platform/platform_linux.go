@@ -0,0 +1,6 @@
+//go:build linux
+
+package platform
+
+// Device is a block device
+type Device struct{}
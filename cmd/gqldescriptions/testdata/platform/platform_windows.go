@@ -0,0 +1,6 @@
+//go:build windows
+
+package platform
+
+// Device is a volume
+type Device struct{}
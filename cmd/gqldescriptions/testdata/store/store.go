@@ -0,0 +1,23 @@
+package store
+
+// User is a customer of the store
+type User struct {
+	// Name is the display name
+	Name  string
+	Email string // Email is the contact address
+	notes string
+}
+
+// Orders lists the orders placed by the user
+func (u *User) Orders() ([]*Order, error) {
+	return nil, nil
+}
+
+type (
+	// Order is a purchase
+	Order struct {
+		Total float64
+	}
+)
+
+func helper() {}
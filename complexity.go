@@ -0,0 +1,340 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// DefaultFieldCost is the cost of a field without cost tag or estimate
+const DefaultFieldCost = 1
+
+// ComplexityEstimator estimates the cost of a selected field from its
+// arguments. Returning false falls back to the field's cost tag option, or
+// DefaultFieldCost without one.
+type ComplexityEstimator func(typeName string, fieldName string, args map[string]interface{}) (int, bool)
+
+// WithMaxQueryComplexity rejects operations whose total cost, the sum of the
+// costs of all their selected fields, exceeds max. Field costs come from the
+// cost tag option (gql:"search,cost=10") or a ComplexityEstimator.
+func (b *SchemaBuilder) WithMaxQueryComplexity(max int) *SchemaBuilder {
+	b.maxCost = max
+	return b
+}
+
+// WithQueryComplexityEstimator sets the function estimating field costs for
+// WithMaxQueryComplexity
+func (b *SchemaBuilder) WithQueryComplexityEstimator(estimator ComplexityEstimator) *SchemaBuilder {
+	b.costEstimator = estimator
+	return b
+}
+
+// costContext holds what measuring an operation needs beyond its selections
+type costContext struct {
+	schema    *graphql.Schema
+	fragments map[string]ast.Definition
+	variables map[string]interface{}
+	// spreading holds the fragments being measured, operations are measured
+	// before validation rejects cyclic fragments
+	spreading map[string]bool
+}
+
+// complexityError reports an operation exceeding the maximum cost
+func (b *SchemaBuilder) complexityError(cost int) error {
+	return fmt.Errorf("query complexity %d exceeds the maximum of %d", cost, b.maxCost)
+}
+
+// operationCost measures the operation of document named operationName, or
+// its only operation, returning false if there is no such operation
+func (b *SchemaBuilder) operationCost(schema *graphql.Schema, document *ast.Document, operationName string, variables map[string]interface{}) (int, bool) {
+	cc := &costContext{schema: schema, fragments: map[string]ast.Definition{}, variables: variables}
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			cc.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			name := ""
+			if definition.Name != nil {
+				name = definition.Name.Value
+			}
+			if operationName == "" || name == operationName {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		return 0, false
+	}
+
+	var root *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		root = schema.QueryType()
+	case ast.OperationTypeMutation:
+		root = schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = schema.SubscriptionType()
+	}
+	if root == nil {
+		return 0, false
+	}
+	return b.selectionCost(cc, root, operation.SelectionSet), true
+}
+
+// complexityKey is the context key of the complexity error of an operation
+type complexityKey struct{}
+
+// complexityExtension rejects operations run by graphql.Do whose cost
+// exceeds the maximum once, before any of their fields is resolved
+type complexityExtension struct {
+	b *SchemaBuilder
+}
+
+func (e *complexityExtension) Name() string {
+	return "QueryComplexity"
+}
+
+// Init measures the operation, which is only rejected in ExecutionDidStart
+// so that parse and validation errors are reported first
+func (e *complexityExtension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	document, err := parser.Parse(parser.ParseParams{Source: p.RequestString})
+	if err != nil {
+		return ctx
+	}
+	if cost, ok := e.b.operationCost(&p.Schema, document, p.OperationName, p.VariableValues); ok && cost > e.b.maxCost {
+		return context.WithValue(ctx, complexityKey{}, e.b.complexityError(cost))
+	}
+	return ctx
+}
+
+func (e *complexityExtension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+func (e *complexityExtension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+// ExecutionDidStart rejects operations over the maximum cost, graphql-go
+// turning the panic into the only error of the result
+func (e *complexityExtension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	if err, ok := ctx.Value(complexityKey{}).(error); ok {
+		panic(err)
+	}
+	return ctx, func(*graphql.Result) {}
+}
+
+func (e *complexityExtension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(interface{}, error) {}
+}
+
+func (e *complexityExtension) HasResult() bool {
+	return false
+}
+
+func (e *complexityExtension) GetResult(ctx context.Context) interface{} {
+	return nil
+}
+
+// schemaExtensions returns the extensions of the schema config, including
+// the complexity limit when set
+func (b *SchemaBuilder) schemaExtensions() []graphql.Extension {
+	if b.maxCost <= 0 {
+		return b.extensions
+	}
+	return append(append([]graphql.Extension{}, b.extensions...), &complexityExtension{b: b})
+}
+
+// limitSubscriptionComplexity wraps the subscribe functions of the root
+// subscription fields, as graphql.Subscribe runs no extensions. Each
+// subscription selects a single root field, so it is measured once.
+func (b *SchemaBuilder) limitSubscriptionComplexity(root *graphql.Object) {
+	if root == nil || b.maxCost <= 0 {
+		return
+	}
+
+	for _, field := range root.Fields() {
+		subscribe := field.Subscribe
+		if subscribe == nil {
+			continue
+		}
+		field.Subscribe = func(p graphql.ResolveParams) (interface{}, error) {
+			if operation, ok := p.Info.Operation.(*ast.OperationDefinition); ok {
+				cc := &costContext{schema: &p.Info.Schema, fragments: p.Info.Fragments, variables: p.Info.VariableValues}
+				if cost := b.selectionCost(cc, p.Info.ParentType, operation.SelectionSet); cost > b.maxCost {
+					return nil, b.complexityError(cost)
+				}
+			}
+			return subscribe(p)
+		}
+	}
+}
+
+// selectionCost sums the costs of the fields selected on parent, following
+// fragments and nested selections
+func (b *SchemaBuilder) selectionCost(cc *costContext, parent graphql.Type, selectionSet *ast.SelectionSet) int {
+	if selectionSet == nil {
+		return 0
+	}
+
+	cost := 0
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			fields := compositeFields(parent)
+			if fields == nil {
+				continue
+			}
+			field, ok := fields[selection.Name.Value]
+			if !ok {
+				continue
+			}
+			cost += b.fieldCost(cc, parent.Name(), field, selection)
+			cost += b.selectionCost(cc, graphql.GetNamed(field.Type).(graphql.Type), selection.SelectionSet)
+		case *ast.InlineFragment:
+			fragmentType := parent
+			if selection.TypeCondition != nil {
+				fragmentType = cc.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			cost += b.selectionCost(cc, fragmentType, selection.SelectionSet)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := cc.fragments[name].(*ast.FragmentDefinition)
+			if !ok || cc.spreading[name] {
+				continue
+			}
+			if cc.spreading == nil {
+				cc.spreading = map[string]bool{}
+			}
+			cc.spreading[name] = true
+			cost += b.selectionCost(cc, cc.schema.Type(fragment.TypeCondition.Name.Value), fragment.SelectionSet)
+			delete(cc.spreading, name)
+		}
+	}
+	return cost
+}
+
+// compositeFields returns the fields of object and interface types
+func compositeFields(t graphql.Type) graphql.FieldDefinitionMap {
+	switch t := t.(type) {
+	case *graphql.Object:
+		return t.Fields()
+	case *graphql.Interface:
+		return t.Fields()
+	}
+	return nil
+}
+
+func (b *SchemaBuilder) fieldCost(cc *costContext, typeName string, definition *graphql.FieldDefinition, field *ast.Field) int {
+	fieldName := field.Name.Value
+	if b.costEstimator != nil {
+		argTypes := map[string]graphql.Input{}
+		for _, arg := range definition.Args {
+			argTypes[arg.Name()] = arg.Type
+		}
+		args := map[string]interface{}{}
+		for _, arg := range field.Arguments {
+			args[arg.Name.Value] = literalArgument(argTypes[arg.Name.Value], arg.Value, cc.variables)
+		}
+		if cost, ok := b.costEstimator(typeName, fieldName, args); ok {
+			return cost
+		}
+	}
+
+	if cost, ok := b.fieldCosts[typeName+"."+fieldName]; ok {
+		return cost
+	}
+	return DefaultFieldCost
+}
+
+// leafType parses the values of scalars and enums
+type leafType interface {
+	ParseValue(value interface{}) interface{}
+	ParseLiteral(valueAST ast.Value) interface{}
+}
+
+// literalArgument coerces an argument literal by its type t, so that
+// estimators receive the values resolvers do, such as int for Int literals
+func literalArgument(t graphql.Input, value ast.Value, variables map[string]interface{}) interface{} {
+	if variable, ok := value.(*ast.Variable); ok {
+		return variableArgument(t, variables[variable.Name.Value])
+	}
+
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return literalArgument(t.OfType, value, variables)
+	case *graphql.List:
+		list, ok := value.(*ast.ListValue)
+		if !ok {
+			return []interface{}{literalArgument(t.OfType, value, variables)}
+		}
+		items := make([]interface{}, 0, len(list.Values))
+		for _, item := range list.Values {
+			items = append(items, literalArgument(t.OfType, item, variables))
+		}
+		return items
+	case *graphql.InputObject:
+		object, ok := value.(*ast.ObjectValue)
+		if !ok {
+			return nil
+		}
+		fields := t.Fields()
+		values := make(map[string]interface{}, len(object.Fields))
+		for _, field := range object.Fields {
+			if definition, ok := fields[field.Name.Value]; ok {
+				values[field.Name.Value] = literalArgument(definition.Type, field.Value, variables)
+			}
+		}
+		return values
+	case leafType:
+		return t.ParseLiteral(value)
+	}
+	return parseJSONLiteral(value)
+}
+
+// variableArgument coerces a variable value by its type t, like
+// literalArgument
+func variableArgument(t graphql.Input, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return variableArgument(t.OfType, value)
+	case *graphql.List:
+		list, ok := value.([]interface{})
+		if !ok {
+			return []interface{}{variableArgument(t.OfType, value)}
+		}
+		items := make([]interface{}, 0, len(list))
+		for _, item := range list {
+			items = append(items, variableArgument(t.OfType, item))
+		}
+		return items
+	case *graphql.InputObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := t.Fields()
+		values := make(map[string]interface{}, len(object))
+		for name, item := range object {
+			if definition, ok := fields[name]; ok {
+				values[name] = variableArgument(definition.Type, item)
+			}
+		}
+		return values
+	case leafType:
+		return t.ParseValue(value)
+	}
+	return value
+}
@@ -0,0 +1,187 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+type CostItem struct {
+	Name    string `gql:"name"`
+	Details string `gql:"details,cost=5"`
+}
+
+type SearchInput struct {
+	Limit int `gql:"limit"`
+}
+
+type CostQuery struct {
+	Featured *CostItem `gql:"featured"`
+}
+
+func (q *CostQuery) Search(input SearchInput) ([]*CostItem, error) {
+	return []*CostItem{{Name: "a", Details: "first"}}, nil
+}
+
+func runCostQuery(t *testing.T, b *SchemaBuilder, query string) *graphql.Result {
+	return runCostQueryWithVariables(t, b, query, nil)
+}
+
+func runCostQueryWithVariables(t *testing.T, b *SchemaBuilder, query string, variables map[string]interface{}) *graphql.Result {
+	schema, err := b.WithQuery(&CostQuery{Featured: &CostItem{Name: "f"}}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  query,
+		VariableValues: variables,
+		Context:        context.Background(),
+	})
+}
+
+func TestMaxQueryComplexity(t *testing.T) {
+	cases := []struct {
+		query       string
+		expectError bool
+	}{
+		// search (1) + name (1) + featured (1) + name (1)
+		{query: `{ search(limit: 1) { name } featured { name } }`},
+		// search (1) + name (1) + details (5)
+		{query: `{ search(limit: 1) { name ...details } } fragment details on CostItem { details }`, expectError: true},
+	}
+
+	for _, c := range cases {
+		result := runCostQuery(t, NewSchemaBuilder().WithMaxQueryComplexity(6), c.query)
+		if c.expectError {
+			if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, "query complexity 7 exceeds the maximum of 6") {
+				t.Fatalf("expected complexity error, got %v", result.Errors)
+			}
+			continue
+		}
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+	}
+}
+
+func TestQueryComplexityEstimator(t *testing.T) {
+	b := NewSchemaBuilder().
+		WithMaxQueryComplexity(20).
+		WithQueryComplexityEstimator(func(typeName string, fieldName string, args map[string]interface{}) (int, bool) {
+			if typeName == "CostQuery" && fieldName == "search" {
+				limit, _ := args["limit"].(int)
+				return limit, true
+			}
+			return 0, false
+		})
+
+	if result := runCostQuery(t, b, `{ search(limit: 10) { name } }`); result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	result := runCostQuery(t, b, `{ search(limit: 50) { name } }`)
+	if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, "query complexity 51") {
+		t.Fatalf("expected complexity error, got %v", result.Errors)
+	}
+}
+
+func TestMaxQueryComplexityRejectsOnce(t *testing.T) {
+	// search (1) + name (1) + details (5) + featured (1) + details (5)
+	result := runCostQuery(t, NewSchemaBuilder().WithMaxQueryComplexity(6), `{ search(limit: 1) { name details } featured { details } }`)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "query complexity 13 exceeds the maximum of 6") {
+		t.Fatalf("expected a single complexity error, got %v", result.Errors)
+	}
+	if result.Data != nil {
+		t.Fatalf("expected no data, got %v", result.Data)
+	}
+}
+
+func TestQueryComplexityCyclicFragments(t *testing.T) {
+	b := NewSchemaBuilder().WithMaxQueryComplexity(10)
+	schema, err := b.WithQuery(&CostQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Operations are measured before validation, cyclic fragments must not
+	// recurse forever
+	document, err := parser.Parse(parser.ParseParams{
+		Source: `query { ...A } fragment A on CostQuery { featured { name } ...B } fragment B on CostQuery { ...A }`,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cost, ok := b.operationCost(schema, document, "", nil); !ok || cost != 2 {
+		t.Fatalf("expected a cost of 2, got %d", cost)
+	}
+
+	// Fragments spread more than once are counted each time
+	result := runCostQuery(t, NewSchemaBuilder().WithMaxQueryComplexity(11), `{ featured { ...D } other: featured { ...D } } fragment D on CostItem { details }`)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "query complexity 12 exceeds the maximum of 11") {
+		t.Fatalf("expected a complexity error, got %v", result.Errors)
+	}
+}
+
+func TestQueryComplexityEstimatorVariables(t *testing.T) {
+	limits := []interface{}{}
+	b := NewSchemaBuilder().
+		WithMaxQueryComplexity(20).
+		WithQueryComplexityEstimator(func(typeName string, fieldName string, args map[string]interface{}) (int, bool) {
+			if fieldName == "search" {
+				limits = append(limits, args["limit"])
+			}
+			return 0, false
+		})
+
+	runCostQuery(t, b, `{ search(limit: 10) { name } }`)
+	// JSON decoded variables are float64
+	runCostQueryWithVariables(t, b, `query($limit: Int) { search(limit: $limit) { name } }`, map[string]interface{}{"limit": float64(10)})
+
+	if expected := []interface{}{10, 10}; !reflect.DeepEqual(limits, expected) {
+		t.Fatalf("expected the same int limits, got %#v", limits)
+	}
+}
+
+type CostSubscription struct{}
+
+func (s *CostSubscription) Items(ctx context.Context) (<-chan *CostItem, error) {
+	items := make(chan *CostItem, 1)
+	items <- &CostItem{Name: "a", Details: "first"}
+	close(items)
+	return items, nil
+}
+
+func TestMaxSubscriptionComplexity(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithMaxQueryComplexity(3).
+		WithQuery(&CostQuery{}).
+		WithSubscription(&CostSubscription{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := map[string]string{
+		`subscription { items { name } }`:         "",
+		`subscription { items { name details } }`: "query complexity 7 exceeds the maximum of 3",
+	}
+	for query, message := range cases {
+		for result := range graphql.Subscribe(graphql.Params{
+			Schema:        *schema,
+			RequestString: query,
+			Context:       context.Background(),
+		}) {
+			if message == "" && result.Errors != nil {
+				t.Fatalf("%s: expected no errors, got %v", query, result.Errors)
+			}
+			if message != "" && (len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, message)) {
+				t.Fatalf("%s: expected a complexity error, got %v", query, result.Errors)
+			}
+		}
+	}
+}
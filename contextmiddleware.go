@@ -0,0 +1,46 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+)
+
+// ContextMiddleware derives the context passed to a resolver, such as one
+// carrying a request scoped database transaction
+type ContextMiddleware func(ctx context.Context, info graphql.ResolveInfo) (context.Context, error)
+
+// WithContextMiddleware adds a middleware running before each resolver,
+// including those of struct fields, whose context replaces the one of the resolve params. Middlewares run in
+// the order they are added, an error fails the field.
+func (b *SchemaBuilder) WithContextMiddleware(middleware ContextMiddleware) *SchemaBuilder {
+	b.middlewares = append(b.middlewares, middleware)
+	return b
+}
+
+// FieldMiddleware wraps the resolver of a field, such as to rate limit it
+type FieldMiddleware func(next graphql.FieldResolveFn) graphql.FieldResolveFn
+
+// WithFieldMiddleware adds a middleware wrapping the resolver of the named
+// field only, whether a method or a struct field. Middlewares of a field run in the order they are added.
+func (b *SchemaBuilder) WithFieldMiddleware(field string, middleware FieldMiddleware) *SchemaBuilder {
+	b.fieldMiddlewares[field] = append(b.fieldMiddlewares[field], middleware)
+	return b
+}
+
+// withContextMiddlewares runs resolve with the context derived by middlewares
+func withContextMiddlewares(middlewares []ContextMiddleware, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if p.Context == nil {
+			p.Context = context.Background()
+		}
+		for _, middleware := range middlewares {
+			ctx, err := middleware(p.Context, p.Info)
+			if err != nil {
+				return nil, err
+			}
+			p.Context = ctx
+		}
+		return resolve(p)
+	}
+}
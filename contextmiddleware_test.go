@@ -0,0 +1,175 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type txKey struct{}
+
+type TxHost struct{}
+
+func (h *TxHost) Tx(ctx context.Context) (string, error) {
+	tx, _ := ctx.Value(txKey{}).(string)
+	return tx, nil
+}
+
+func TestWithContextMiddleware(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithContextMiddleware(func(ctx context.Context, info graphql.ResolveInfo) (context.Context, error) {
+			return context.WithValue(ctx, txKey{}, "tx-"+info.FieldName), nil
+		}).
+		WithQuery(&TxHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ tx }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"tx": "tx-tx"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestContextMiddlewareError(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithContextMiddleware(func(ctx context.Context, info graphql.ResolveInfo) (context.Context, error) {
+			return nil, errors.New("no transaction")
+		}).
+		WithQuery(&TxHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ tx }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "no transaction" {
+		t.Fatalf("expected no transaction error, got %v", result.Errors)
+	}
+}
+
+type LimitedHost struct{}
+
+func (h *LimitedHost) CreateUser() (string, error) {
+	return "created", nil
+}
+
+func (h *LimitedHost) DeleteUser() (string, error) {
+	return "deleted", nil
+}
+
+func TestWithFieldMiddleware(t *testing.T) {
+	calls := []string{}
+	limit := func(name string) FieldMiddleware {
+		return func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+			return func(p graphql.ResolveParams) (interface{}, error) {
+				calls = append(calls, name+":"+p.Info.FieldName)
+				return next(p)
+			}
+		}
+	}
+
+	schema, err := NewSchemaBuilder().
+		WithFieldMiddleware("createUser", limit("first")).
+		WithFieldMiddleware("createUser", limit("second")).
+		WithMutation(&LimitedHost{}).
+		WithQuery(&TxHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `mutation { createUser deleteUser }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := []string{"first:createUser", "second:createUser"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected %v, got %v", expected, calls)
+	}
+}
+
+type MaskedUser struct {
+	Name  string `gql:"name"`
+	Email string `gql:"email"`
+}
+
+type MaskedHost struct{}
+
+func (h *MaskedHost) User() (*MaskedUser, error) {
+	return &MaskedUser{Name: "ada", Email: "ada@example.com"}, nil
+}
+
+func TestFieldMiddlewareOnStructFields(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithFieldMiddleware("email", func(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+			return func(p graphql.ResolveParams) (interface{}, error) {
+				return "hidden", nil
+			}
+		}).
+		WithQuery(&MaskedHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ user { name email } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"user": map[string]interface{}{"name": "ada", "email": "hidden"}}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestContextMiddlewareOnStructFields(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithContextMiddleware(func(ctx context.Context, info graphql.ResolveInfo) (context.Context, error) {
+			if info.FieldName == "email" {
+				return nil, errors.New("forbidden")
+			}
+			return ctx, nil
+		}).
+		WithQuery(&MaskedHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ user { name email } }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "forbidden" {
+		t.Fatalf("expected a forbidden error, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,53 @@
+package gql
+
+import (
+	"net/http"
+)
+
+// WithCORS allows cross-origin requests from the given origins, or from any
+// origin with "*", answering preflight OPTIONS requests with 204
+func WithCORS(origins ...string) HandlerOption {
+	return func(h *Handler) {
+		h.corsOrigins = origins
+	}
+}
+
+// setCORSHeaders sets the CORS headers of a request from an allowed origin
+// and reports whether it is a preflight request, which is fully answered
+func (h *Handler) setCORSHeaders(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if len(h.corsOrigins) == 0 || origin == "" {
+		return false
+	}
+
+	header := w.Header()
+	header.Add("Vary", "Origin")
+	allowed := ""
+	for _, o := range h.corsOrigins {
+		if o == "*" {
+			allowed = "*"
+			break
+		}
+		if o == origin {
+			allowed = origin
+			break
+		}
+	}
+	if allowed == "" {
+		return false
+	}
+	header.Set("Access-Control-Allow-Origin", allowed)
+
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+	header.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+		header.Set("Access-Control-Allow-Headers", headers)
+	} else {
+		header.Set("Access-Control-Allow-Headers", "Content-Type")
+	}
+	header.Set("Access-Control-Max-Age", "86400")
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
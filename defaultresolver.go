@@ -0,0 +1,51 @@
+package gql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WithDefaultResolver enables or disables resolving tagged struct fields by
+// their Go field, enabled by default. graphql-go's default resolver matches
+// GraphQL field names against Go field names and json tags, so fields renamed
+// through gql tags would otherwise resolve to null.
+func (b *SchemaBuilder) WithDefaultResolver(enabled bool) *SchemaBuilder {
+	b.defaultResolver = enabled
+	return b
+}
+
+// structFieldResolver resolves a GraphQL field from the Go struct field named
+// goName of the source, or of the bound instance for root types
+func structFieldResolver(goName string, bound interface{}, isBound bool) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if _, ok := p.Source.(graphql.FieldResolver); ok {
+			return graphql.DefaultResolveFn(p)
+		}
+
+		source := reflect.ValueOf(p.Source)
+		if isBound {
+			source = reflect.ValueOf(bound)
+		}
+		for source.Kind() == reflect.Ptr || source.Kind() == reflect.Interface {
+			if source.IsNil() {
+				return nil, nil
+			}
+			source = source.Elem()
+		}
+		if source.Kind() != reflect.Struct {
+			return graphql.DefaultResolveFn(p)
+		}
+
+		field, ok := source.Type().FieldByName(goName)
+		if !ok {
+			return graphql.DefaultResolveFn(p)
+		}
+		value, err := source.FieldByIndexErr(field.Index)
+		if err != nil {
+			// Promoted through a nil embedded pointer
+			return nil, nil
+		}
+		return readValue(valueInterface(value))
+	}
+}
@@ -0,0 +1,144 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type RenamedAudit struct {
+	CreatedBy string `gql:"author"`
+}
+
+type RenamedUser struct {
+	*RenamedAudit
+	FullName string `gql:"name"`
+	Mail     string `gql:"email"`
+}
+
+type RenamedQuery struct {
+	Current *RenamedUser `gql:"me"`
+}
+
+func (q *RenamedQuery) Users() ([]*RenamedUser, error) {
+	return []*RenamedUser{
+		{RenamedAudit: &RenamedAudit{CreatedBy: "admin"}, FullName: "Ada", Mail: "ada@example.com"},
+		{FullName: "Bob", Mail: "bob@example.com"},
+	}, nil
+}
+
+func TestDefaultResolver(t *testing.T) {
+	query := &RenamedQuery{Current: &RenamedUser{FullName: "Me", Mail: "me@example.com"}}
+	schema, err := NewSchemaBuilder().WithQuery(query).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ me { name email } users { name email author } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"me": map[string]interface{}{"name": "Me", "email": "me@example.com"},
+		"users": []interface{}{
+			map[string]interface{}{"name": "Ada", "email": "ada@example.com", "author": "admin"},
+			map[string]interface{}{"name": "Bob", "email": "bob@example.com", "author": nil},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestDefaultResolverDisabled(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithDefaultResolver(false).WithQuery(&RenamedQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ users { name } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	// graphql-go's default resolver can't find renamed fields
+	expected := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": nil},
+			map[string]interface{}{"name": nil},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type AliasedProfile struct {
+	FullName string
+	Age      int
+	Name     string `gql:"name,from=FullName"`
+	Years    int    `gql:"years,nonNull,from=Age"`
+}
+
+type AliasedQuery struct{}
+
+func (q *AliasedQuery) Profile() (*AliasedProfile, error) {
+	return &AliasedProfile{FullName: "Ada Lovelace", Age: 36, Name: "ignored"}, nil
+}
+
+func TestAliasedFields(t *testing.T) {
+	for _, defaultResolver := range []bool{true, false} {
+		schema, err := NewSchemaBuilder().WithDefaultResolver(defaultResolver).WithQuery(&AliasedQuery{}).BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		fields := schema.Type("AliasedProfile").(*graphql.Object).Fields()
+		if fieldType := fields["years"].Type.String(); fieldType != "Int!" {
+			t.Fatalf("expected Int!, got %s", fieldType)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: `{ profile { name years } }`,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		expected := map[string]interface{}{
+			"profile": map[string]interface{}{"name": "Ada Lovelace", "years": 36},
+		}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("expected %v, got %v", expected, result.Data)
+		}
+	}
+}
+
+type MisaliasedProfile struct {
+	Name string `gql:"name,from=Missing"`
+}
+
+type MisaliasedQuery struct{}
+
+func (q *MisaliasedQuery) Profile() (*MisaliasedProfile, error) {
+	return &MisaliasedProfile{}, nil
+}
+
+func TestAliasedFieldMissingSource(t *testing.T) {
+	if _, err := NewSchemaBuilder().WithQuery(&MisaliasedQuery{}).BuildSchema(); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
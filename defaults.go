@@ -0,0 +1,83 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// inputDefaults returns the value returned by the Defaults method of an input
+// struct, whose non-zero fields are the default values of the input fields:
+//
+//	func (ListInput) Defaults() ListInput {
+//		return ListInput{Limit: 25}
+//	}
+func inputDefaults(definition reflect.Type) (reflect.Value, bool) {
+	method, ok := reflect.PointerTo(definition).MethodByName("Defaults")
+	if !ok || !isInputDefaultsMethod(definition, method) {
+		return reflect.Value{}, false
+	}
+
+	defaults := method.Func.Call([]reflect.Value{reflect.New(definition)})[0]
+	if defaults.Kind() == reflect.Ptr {
+		if defaults.IsNil() {
+			return reflect.Value{}, false
+		}
+		defaults = defaults.Elem()
+	}
+	return defaults, true
+}
+
+// isInputDefaultsMethod reports whether method of definition, or a pointer
+// to it, has the signature of input defaults, returning the struct itself
+func isInputDefaultsMethod(definition reflect.Type, method reflect.Method) bool {
+	if method.Name != "Defaults" || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 {
+		return false
+	}
+	out := method.Type.Out(0)
+	return out == definition || out == reflect.PointerTo(definition)
+}
+
+// pruneDefaultsGetters removes the fields of the Defaults methods of types
+// exposed as objects before being found to be input types
+func (b *SchemaBuilder) pruneDefaultsGetters() {
+	name := b.fieldNamer("Defaults")
+	for t := range b.defaultsGetters {
+		if fields, ok := b.fieldsCache[t]; ok && b.inputDefaultTypes[t] {
+			delete(fields, name)
+		}
+	}
+}
+
+// applyFieldDefault sets the default value of an input field from its value
+// in the Defaults of its struct, unless it is zero or a default tag is set
+func applyFieldDefault(fieldConfig *graphql.InputObjectFieldConfig, field *reflect.StructField, value reflect.Value) error {
+	if fieldConfig.DefaultValue != nil {
+		return nil
+	}
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.IsZero() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String:
+	default:
+		return fmt.Errorf("Invalid default value for field %s: unsupported %s default", field.Name, value.Kind())
+	}
+
+	defaultValue, err := parseDefaultValue(fmt.Sprint(value.Interface()), field.Type)
+	if err != nil {
+		return fmt.Errorf("Invalid default value for field %s: %w", field.Name, err)
+	}
+	fieldConfig.DefaultValue = defaultValue
+	return nil
+}
@@ -0,0 +1,112 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type ListingInput struct {
+	Limit  int    `gql:"limit"`
+	Order  string `gql:"order,default=asc"`
+	Filter string `gql:"filter"`
+}
+
+func (ListingInput) Defaults() ListingInput {
+	return ListingInput{Limit: 25, Order: "desc"}
+}
+
+type ListingHost struct{}
+
+func (h *ListingHost) Listing(input ListingInput) (string, error) {
+	return fmt.Sprintf("%d %s %q", input.Limit, input.Order, input.Filter), nil
+}
+
+func TestInputDefaultsMethod(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ListingHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	args := map[string]*graphql.Argument{}
+	for _, arg := range schema.QueryType().Fields()["listing"].Args {
+		args[arg.Name()] = arg
+	}
+	if args["limit"].DefaultValue != 25 {
+		t.Fatalf("expected limit default 25, got %v", args["limit"].DefaultValue)
+	}
+	// Default tags take precedence over Defaults
+	if args["order"].DefaultValue != "asc" {
+		t.Fatalf("expected order default asc, got %v", args["order"].DefaultValue)
+	}
+	if args["filter"].DefaultValue != nil {
+		t.Fatalf("expected no filter default, got %v", args["filter"].DefaultValue)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ defaults: listing overridden: listing(limit: 5) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"defaults": `25 asc ""`, "overridden": `5 asc ""`}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type Thresholds struct {
+	Warn int `gql:"warn"`
+}
+
+type Sensor struct {
+	Name string `gql:"name"`
+}
+
+// Defaults returns another type, so it is an ordinary getter
+func (s *Sensor) Defaults() Thresholds {
+	return Thresholds{Warn: 80}
+}
+
+type SensorHost struct{}
+
+func (h *SensorHost) Sensor(input ListingInput) (*Sensor, error) {
+	return &Sensor{Name: "s1"}, nil
+}
+
+func (h *SensorHost) Echo(input ListingInput) (*ListingInput, error) {
+	return &input, nil
+}
+
+func TestDefaultsGetterOnOutputTypes(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&SensorHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, ok := schema.Type("ListingInput").(*graphql.Object).Fields()["defaults"]; ok {
+		t.Fatalf("expected the input defaults method not to be exposed")
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ sensor { name defaults { warn } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"sensor": map[string]interface{}{"name": "s1", "defaults": map[string]interface{}{"warn": 80}},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
@@ -0,0 +1,53 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Describe lists the resolvers bound to the root fields, with the function
+// name, input type and output type detected for each of them. It's meant for
+// debugging how the builder interprets resolver signatures and doesn't need
+// the schema to be built.
+func (b *SchemaBuilder) Describe() string {
+	roots := []struct {
+		kind RootType
+		root interface{}
+	}{
+		{Query, b.query},
+		{Mutation, b.mutation},
+		{Subscription, b.subscription},
+	}
+
+	blocks := []string{}
+	for _, r := range roots {
+		if r.root == nil {
+			continue
+		}
+		blocks = append(blocks, b.describeRoot(r.kind, reflect.TypeOf(r.root)))
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+func (b *SchemaBuilder) describeRoot(kind RootType, definition reflect.Type) string {
+	lines := []string{}
+	for i := 0; i < definition.NumMethod(); i++ {
+		method := definition.Method(i)
+		resolveInfo, err := b.newResolveInfo(method.Func)
+		if err != nil {
+			continue
+		}
+
+		input := "none"
+		if resolveInfo.Input != nil {
+			input = resolveInfo.Input.Type.String()
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s(input: %s) %s",
+			b.fieldNamer(method.Name), resolveInfo.FuncName(), input, resolveInfo.OutputType()))
+	}
+	sort.Strings(lines)
+
+	return fmt.Sprintf("%s %s\n%s", strings.ToLower(string(kind)), definition, strings.Join(lines, "\n"))
+}
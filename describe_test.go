@@ -0,0 +1,24 @@
+package gql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	description := NewSchemaBuilder().
+		WithQuery(&FoundHost{}).
+		WithMutation(&EchoMutation{}).
+		Describe()
+
+	for _, expected := range []string{
+		"query *gql.FoundHost",
+		"  findUser: github.com/kadirpekel/gql.(*FoundHost).FindUser(input: gql.FoundInput) *gql.ListUser",
+		"mutation *gql.EchoMutation",
+		"  createUser: github.com/kadirpekel/gql.(*EchoMutation).CreateUser(input: gql.CreateEchoUserInput) *gql.EchoUser",
+	} {
+		if !strings.Contains(description, expected) {
+			t.Errorf("expected description to contain %q, got:\n%s", expected, description)
+		}
+	}
+}
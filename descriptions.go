@@ -0,0 +1,25 @@
+package gql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WithDescriptions sets the descriptions of object types, keyed by their Go
+// type name, and of fields and arguments, keyed by the Go type and field or
+// method name such as "User.FirstName". The map is typically generated from
+// doc comments by cmd/gqldescriptions. Descriptions set by gql tags take
+// precedence.
+func (b *SchemaBuilder) WithDescriptions(descriptions map[string]string) *SchemaBuilder {
+	b.descriptions = descriptions
+	return b
+}
+
+// describeField sets the description of a field built from the Go member
+// goName of definition unless it has one
+func (b *SchemaBuilder) describeField(field *graphql.Field, definition reflect.Type, goName string) {
+	if field.Description == "" {
+		field.Description = b.descriptions[definition.Name()+"."+goName]
+	}
+}
@@ -0,0 +1,69 @@
+package gql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type DescribedUser struct {
+	FirstName string `gql:"firstName"`
+	LastName  string `gql:"lastName"`
+}
+
+func (u *DescribedUser) Initials() string {
+	return u.FirstName[:1] + u.LastName[:1]
+}
+
+type DescribedInput struct {
+	ID   string `gql:"id,nonNull"`
+	Name string `gql:"name,description=Name filter"`
+}
+
+type DescribedHost struct{}
+
+func (h *DescribedHost) User(input DescribedInput) (*DescribedUser, error) {
+	return &DescribedUser{FirstName: "Ada", LastName: "Lovelace"}, nil
+}
+
+func TestWithDescriptions(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithDescriptions(map[string]string{
+			"DescribedUser":           "A registered user",
+			"DescribedUser.FirstName": "Given name",
+			"DescribedUser.Initials":  "First letters of the names",
+			"DescribedHost.User":      "Finds a user",
+			"DescribedInput.ID":       "User ID",
+			"DescribedInput.Name":     "Overridden by the tag",
+		}).
+		WithQuery(&DescribedHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	user := schema.Type("DescribedUser").(*graphql.Object)
+	queryField := schema.QueryType().Fields()["user"]
+	cases := []struct {
+		actual   string
+		expected string
+	}{
+		{user.Description(), "A registered user"},
+		{user.Fields()["firstName"].Description, "Given name"},
+		{user.Fields()["initials"].Description, "First letters of the names"},
+		{queryField.Description, "Finds a user"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, c.actual)
+		}
+	}
+
+	// Argument descriptions from gql tags take precedence
+	argDescriptions := map[string]string{"id": "User ID", "name": "Name filter"}
+	for _, arg := range queryField.Args {
+		if arg.Description() != argDescriptions[arg.Name()] {
+			t.Errorf("expected %q, got %q", argDescriptions[arg.Name()], arg.Description())
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WithEnum registers a GraphQL enum for the given Go type.
+// Values map GraphQL enum value names to Go values; values convertible to
+// goType (e.g. untyped int constants for an int-backed type) are converted
+// so that resolver outputs of goType serialize to their enum names.
+func (b *SchemaBuilder) WithEnum(goType reflect.Type, name string, values map[string]interface{}) *SchemaBuilder {
+	valueMap := graphql.EnumValueConfigMap{}
+	enumValues := make(map[string]interface{}, len(values))
+	for valueName, value := range values {
+		v := reflect.ValueOf(value)
+		if v.IsValid() && v.Type() != goType && v.Type().ConvertibleTo(goType) {
+			value = v.Convert(goType).Interface()
+		}
+		valueMap[valueName] = &graphql.EnumValueConfig{Value: value}
+		enumValues[valueName] = value
+	}
+
+	enum := graphql.NewEnum(graphql.EnumConfig{
+		Name:   name,
+		Values: valueMap,
+	})
+
+	b.enumValues[goType] = enumValues
+	b.RegisterCustomType(goType, enum)
+	return b
+}
+
+// enumDecodeHook decodes enum value names into their registered Go values
+// so that enums arriving as plain strings (e.g. inside JSON values) are
+// mapped the same way graphql-go maps enum literals.
+func (b *SchemaBuilder) enumDecodeHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	values, ok := b.enumValues[to]
+	if !ok || from == to || from.Kind() != reflect.String {
+		return data, nil
+	}
+	name := reflect.ValueOf(data).String()
+	value, ok := values[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid value %q for enum %s", name, to)
+	}
+	return value, nil
+}
+
+// NamedConst pairs a Go constant with its name, as Go reflection can't
+// enumerate the constants of a type
+type NamedConst struct {
+	Name  string
+	Value interface{}
+}
+
+// WithEnumType registers an enum named after goType from its constants.
+// Value names are the constant names without prefix in upper snake case,
+// e.g. StatusPendingReview with prefix Status becomes PENDING_REVIEW.
+func (b *SchemaBuilder) WithEnumType(goType reflect.Type, prefix string, consts []NamedConst) *SchemaBuilder {
+	values := make(map[string]interface{}, len(consts))
+	for _, c := range consts {
+		values[enumValueName(strings.TrimPrefix(c.Name, prefix))] = c.Value
+	}
+	return b.WithEnum(goType, goType.Name(), values)
+}
+
+// enumValueName converts a Go identifier to an upper snake case enum value name
+func enumValueName(name string) string {
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			// Split before a new word, keeping acronyms together
+			if unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				sb.WriteRune('_')
+			}
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
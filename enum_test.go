@@ -0,0 +1,297 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Status int
+
+const (
+	Inactive Status = iota
+	Active
+)
+
+type StatusInput struct {
+	Status Status `gql:"status,nonNull"`
+}
+
+type EnumHost struct{}
+
+func (h *EnumHost) EchoStatus(input StatusInput) (Status, error) {
+	return input.Status, nil
+}
+
+func (h *EnumHost) StatusValue(input StatusInput) (int, error) {
+	return int(input.Status), nil
+}
+
+func newStatusSchemaBuilder() *SchemaBuilder {
+	return NewSchemaBuilder().
+		WithEnum(reflect.TypeOf(Status(0)), "Status", map[string]interface{}{
+			"INACTIVE": 0,
+			"ACTIVE":   1,
+		})
+}
+
+func TestEnumFromIntConstants(t *testing.T) {
+	schema, err := newStatusSchemaBuilder().WithQuery(&EnumHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ echoStatus(status: ACTIVE) statusValue(status: ACTIVE) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"echoStatus": "ACTIVE", "statusValue": 1}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestEnumDecodeHook(t *testing.T) {
+	b := newStatusSchemaBuilder()
+	argInfo := NewArgInfo(reflect.TypeOf(StatusInput{}), 1)
+	argInfo.DecodeHook = b.decodeHook()
+
+	value, err := argInfo.ValueFromMap(map[string]interface{}{"Status": "ACTIVE"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status := value.Interface().(StatusInput).Status; status != Active {
+		t.Fatalf("expected %v, got %v", Active, status)
+	}
+
+	_, err = argInfo.ValueFromMap(map[string]interface{}{"Status": "UNKNOWN"})
+	if err == nil {
+		t.Fatalf("expected error for unknown enum value")
+	}
+}
+
+type ReviewState int
+
+const (
+	ReviewStateDraft ReviewState = iota
+	ReviewStatePendingReview
+	ReviewStateHTTPError
+)
+
+type ReviewInput struct {
+	State ReviewState `gql:"state,nonNull"`
+}
+
+type ReviewHost struct{}
+
+func (h *ReviewHost) NextState(input ReviewInput) (ReviewState, error) {
+	return input.State + 1, nil
+}
+
+func TestEnumValueName(t *testing.T) {
+	cases := map[string]string{
+		"Draft":         "DRAFT",
+		"PendingReview": "PENDING_REVIEW",
+		"HTTPError":     "HTTP_ERROR",
+		"ID":            "ID",
+	}
+	for name, expected := range cases {
+		if actual := enumValueName(name); actual != expected {
+			t.Errorf("expected %s, got %s", expected, actual)
+		}
+	}
+}
+
+func TestWithEnumType(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithEnumType(reflect.TypeOf(ReviewState(0)), "ReviewState", []NamedConst{
+			{"ReviewStateDraft", ReviewStateDraft},
+			{"ReviewStatePendingReview", ReviewStatePendingReview},
+			{"ReviewStateHTTPError", ReviewStateHTTPError},
+		}).
+		WithQuery(&ReviewHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	enum, ok := schema.Type("ReviewState").(*graphql.Enum)
+	if !ok {
+		t.Fatalf("expected ReviewState enum, got %v", schema.Type("ReviewState"))
+	}
+	names := map[string]bool{}
+	for _, value := range enum.Values() {
+		names[value.Name] = true
+	}
+	expectedNames := map[string]bool{"DRAFT": true, "PENDING_REVIEW": true, "HTTP_ERROR": true}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("expected %v, got %v", expectedNames, names)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ nextState(state: DRAFT) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"nextState": "PENDING_REVIEW"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type StatusFilter struct {
+	Statuses []Status `gql:"statuses"`
+}
+
+type EnumSliceHost struct{}
+
+func (h *EnumSliceHost) AllStatuses() ([]Status, error) {
+	return []Status{Inactive, Active}, nil
+}
+
+func (h *EnumSliceHost) Filter(input StatusFilter) ([]Status, error) {
+	return input.Statuses, nil
+}
+
+func TestEnumSlices(t *testing.T) {
+	schema, err := newStatusSchemaBuilder().WithQuery(&EnumSliceHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.QueryType().Fields()
+	if fieldType := fields["allStatuses"].Type.String(); fieldType != "[Status]" {
+		t.Fatalf("expected [Status], got %s", fieldType)
+	}
+	if argType := fields["filter"].Args[0].Type.String(); argType != "[Status]" {
+		t.Fatalf("expected [Status] argument, got %s", argType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ allStatuses filter(statuses: [ACTIVE, INACTIVE, ACTIVE]) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"allStatuses": []interface{}{"INACTIVE", "ACTIVE"},
+		"filter":      []interface{}{"ACTIVE", "INACTIVE", "ACTIVE"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestEnumValueFromSlice(t *testing.T) {
+	argInfo := NewArgInfo(reflect.TypeOf([]Status{}), 1)
+	argInfo.DecodeHook = newStatusSchemaBuilder().decodeHook()
+
+	value, err := argInfo.ValueFromSlice([]interface{}{"ACTIVE", Inactive})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []Status{Active, Inactive}
+	if !reflect.DeepEqual(value.Interface(), expected) {
+		t.Fatalf("expected %v, got %v", expected, value.Interface())
+	}
+}
+
+type CountingEnumHost struct {
+	calls int
+}
+
+func (h *CountingEnumHost) EchoStatus(input StatusInput) (Status, error) {
+	h.calls++
+	return input.Status, nil
+}
+
+func TestInvalidEnumArgument(t *testing.T) {
+	host := &CountingEnumHost{}
+	schema, err := newStatusSchemaBuilder().WithQuery(host).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, params := range []graphql.Params{
+		{RequestString: `{ echoStatus(status: UNKNOWN) }`},
+		{RequestString: `{ echoStatus(status: "ACTIVE") }`},
+		{
+			RequestString:  `query($status: Status!) { echoStatus(status: $status) }`,
+			VariableValues: map[string]interface{}{"status": "UNKNOWN"},
+		},
+	} {
+		params.Schema = *schema
+		params.Context = context.Background()
+		result := graphql.Do(params)
+		if len(result.Errors) == 0 {
+			t.Fatalf("expected an error for %s", params.RequestString)
+		}
+	}
+
+	if host.calls != 0 {
+		t.Fatalf("expected the resolver not to be called, got %d calls", host.calls)
+	}
+}
+
+type StatusRule struct {
+	Status   Status   `gql:"status,nonNull"`
+	Fallback []Status `gql:"fallback"`
+}
+
+type StatusPolicyInput struct {
+	Name  string       `gql:"name"`
+	Rule  StatusRule   `gql:"rule"`
+	Rules []StatusRule `gql:"rules"`
+}
+
+type StatusPolicyHost struct{}
+
+func (h *StatusPolicyHost) Policy(input StatusPolicyInput) (string, error) {
+	return fmt.Sprintf("%s %v %v", input.Name, input.Rule, input.Rules), nil
+}
+
+func TestNestedEnumInputs(t *testing.T) {
+	schema, err := newStatusSchemaBuilder().WithQuery(&StatusPolicyHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: *schema,
+		RequestString: `query($rules: [StatusRule]) {
+			literal: policy(name: "a", rule: {status: ACTIVE, fallback: [INACTIVE, ACTIVE]}, rules: [{status: INACTIVE}])
+			variable: policy(name: "b", rules: $rules)
+		}`,
+		VariableValues: map[string]interface{}{
+			"rules": []interface{}{map[string]interface{}{"status": "ACTIVE", "fallback": []interface{}{"INACTIVE"}}},
+		},
+		Context: context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"literal":  "a {1 [0 1]} [{0 []}]",
+		"variable": "b {0 []} [{1 [0]}]",
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
@@ -0,0 +1,108 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+// ExtendType adds fields to the object type named name of a schema built by
+// the builder, such as the root fields of a plugin built by a second builder
+// and copied with ObjectFields. The fields resolve right away and the
+// builder's PrintSchema prints them in an extend type block. Fields already
+// defined on the object are reported as conflicts.
+func (b *SchemaBuilder) ExtendType(schema *graphql.Schema, name string, fields graphql.Fields) error {
+	object, ok := schema.Type(name).(*graphql.Object)
+	if !ok {
+		return fmt.Errorf("object type %s not found", name)
+	}
+	if err := object.Error(); err != nil {
+		return err
+	}
+
+	// Validate everything first so that a failing call leaves the object as is
+	if !hasFieldsMap(object) {
+		return fmt.Errorf("object type %s defines its fields lazily and can't be extended", name)
+	}
+	existing := object.Fields()
+	for fieldName, field := range fields {
+		if _, ok := existing[fieldName]; ok {
+			return fmt.Errorf("conflicting field %s on %s", fieldName, name)
+		}
+		if field == nil || field.Type == nil {
+			return fmt.Errorf("field %s of %s has no type", fieldName, name)
+		}
+	}
+
+	extended := b.typeExtensions[object]
+	for fieldName, field := range fields {
+		object.AddFieldConfig(fieldName, field)
+		extended = append(extended, fieldName)
+	}
+	sort.Strings(extended)
+	b.typeExtensions[object] = extended
+
+	// Register the types referenced by the new fields, the object itself is
+	// already known to the schema
+	defined := object.Fields()
+	if err := object.Error(); err != nil {
+		return err
+	}
+	for fieldName := range fields {
+		field := defined[fieldName]
+		if err := schema.AppendType(graphql.GetNamed(field.Type).(graphql.Type)); err != nil {
+			return err
+		}
+		for _, arg := range field.Args {
+			if err := schema.AppendType(graphql.GetNamed(arg.Type).(graphql.Type)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasFieldsMap reports whether object was configured with a graphql.Fields
+// map, which AddFieldConfig extends, rather than a thunk. graphql-go doesn't
+// expose the config, so it is inspected by reflection.
+func hasFieldsMap(object *graphql.Object) bool {
+	config := reflect.ValueOf(object).Elem().FieldByName("typeConfig")
+	if !config.IsValid() {
+		return false
+	}
+	fields := config.FieldByName("Fields")
+	return fields.IsValid() && !fields.IsNil() && fields.Elem().Type() == reflect.TypeOf(graphql.Fields{})
+}
+
+// ObjectFields returns copies of the field configs of a built object, e.g. to
+// pass the root fields of one schema to ExtendType or into another object.
+// The Query of a schema without queries has no fields to copy.
+func ObjectFields(object *graphql.Object) graphql.Fields {
+	fields := graphql.Fields{}
+	if isPlaceholderObject(object) {
+		return fields
+	}
+	for name, definition := range object.Fields() {
+		args := graphql.FieldConfigArgument{}
+		for _, arg := range definition.Args {
+			args[arg.Name()] = &graphql.ArgumentConfig{
+				Type:         arg.Type,
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.Description(),
+			}
+		}
+
+		fields[name] = &graphql.Field{
+			Name:              definition.Name,
+			Type:              definition.Type,
+			Args:              args,
+			Resolve:           definition.Resolve,
+			Subscribe:         definition.Subscribe,
+			DeprecationReason: definition.DeprecationReason,
+			Description:       definition.Description,
+		}
+	}
+	return fields
+}
@@ -0,0 +1,119 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Plugin struct {
+	Name string `gql:"name"`
+}
+
+type PluginQuery struct{}
+
+func (q *PluginQuery) Plugin() (*Plugin, error) {
+	return &Plugin{Name: "audit"}, nil
+}
+
+func TestExtendType(t *testing.T) {
+	b := NewSchemaBuilder().WithRootName(Query, "Query")
+	schema, err := b.WithQuery(&UsersQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	plugin, err := NewSchemaBuilder().WithQuery(&PluginQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := b.ExtendType(schema, "Query", ObjectFields(plugin.QueryType())); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if schema.Type("Plugin") == nil {
+		t.Fatalf("expected Plugin type to be registered")
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ userName plugin { name } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"userName": "john",
+		"plugin":   map[string]interface{}{"name": "audit"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+
+	sdl := b.PrintSchema(schema)
+	expectedSDL := "type Query {\n  userName: String\n}\n\nextend type Query {\n  plugin: Plugin\n}"
+	if !strings.Contains(sdl, expectedSDL) {
+		t.Fatalf("expected SDL to contain %q, got %q", expectedSDL, sdl)
+	}
+}
+
+func TestExtendTypeErrors(t *testing.T) {
+	b := NewSchemaBuilder().WithRootName(Query, "Query")
+	schema, err := b.WithQuery(&UsersQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := graphql.Fields{
+		"userName": &graphql.Field{Type: graphql.String},
+		"extra":    &graphql.Field{Type: graphql.String},
+	}
+	if err := b.ExtendType(schema, "Query", fields); err == nil {
+		t.Fatalf("expected conflict error, got nil")
+	}
+	if err := b.ExtendType(schema, "Missing", fields); err == nil {
+		t.Fatalf("expected missing type error, got nil")
+	}
+
+	// Failed calls leave the object unchanged
+	if _, ok := schema.QueryType().Fields()["extra"]; ok {
+		t.Fatalf("expected no field to be added by a failed call")
+	}
+	if sdl := b.PrintSchema(schema); strings.Contains(sdl, "extend type") {
+		t.Fatalf("expected no extension, got %s", sdl)
+	}
+}
+
+func TestExtendLazyType(t *testing.T) {
+	b := NewSchemaBuilder().WithLazyFields(true)
+	schema, err := b.WithQuery(&LazyHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err = b.ExtendType(schema, "LazyPost", graphql.Fields{"extra": &graphql.Field{Type: graphql.String}})
+	if err == nil || !strings.Contains(err.Error(), "defines its fields lazily") {
+		t.Fatalf("expected a lazy fields error, got %v", err)
+	}
+}
+
+func TestPrintSchemaWithoutBuilderMetadata(t *testing.T) {
+	b := NewSchemaBuilder().WithRootName(Query, "Query")
+	schema, err := b.WithQuery(&UsersQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := b.ExtendType(schema, "Query", graphql.Fields{"extra": &graphql.Field{Type: graphql.String}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Extensions are recorded by the builder, other schemas are unaffected
+	if sdl := PrintSchema(schema); strings.Contains(sdl, "extend type") || !strings.Contains(sdl, "extra: String") {
+		t.Fatalf("expected extra as a plain field, got %s", sdl)
+	}
+}
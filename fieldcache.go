@@ -0,0 +1,104 @@
+package gql
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WithFieldCache memoizes the results of the resolver for the named field,
+// keyed by its parent type, source and arguments, for ttl. Only results of
+// pure resolvers, whose output depends solely on their source and arguments,
+// should be cached. Sources are keyed by the value of their exported
+// fields, sources that can't be JSON encoded are resolved uncached. Context middlewares and argument validators run on cache hits too.
+func (b *SchemaBuilder) WithFieldCache(field string, ttl time.Duration) *SchemaBuilder {
+	b.fieldCaches[field] = newFieldCache(ttl)
+	return b
+}
+
+type fieldCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// fieldCache is a thread-safe TTL cache of resolver results
+type fieldCache struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	entries   map[string]fieldCacheEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newFieldCache(ttl time.Duration) *fieldCache {
+	return &fieldCache{
+		ttl:     ttl,
+		entries: make(map[string]fieldCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// key hashes the parent type, field, source and arguments, json encoding
+// sorts map keys so equal arguments produce equal keys. Sources are encoded
+// by value rather than address, which is reused once a source is collected.
+func (c *fieldCache) key(p graphql.ResolveParams) (string, error) {
+	parent := ""
+	if p.Info.ParentType != nil {
+		parent = p.Info.ParentType.Name()
+	}
+	encoded, err := json.Marshal([]interface{}{parent, p.Info.FieldName, p.Source, p.Args})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(encoded)), nil
+}
+
+// sweep deletes expired entries, at most once per ttl. c.mu must be held.
+func (c *fieldCache) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	c.lastSweep = now
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *fieldCache) wrap(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		key, err := c.key(p)
+		if err != nil {
+			// Sources and arguments that can't be hashed are resolved uncached
+			return resolve(p)
+		}
+
+		now := c.now()
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		if ok && !now.Before(entry.expires) {
+			delete(c.entries, key)
+			ok = false
+		}
+		c.mu.Unlock()
+		if ok {
+			return entry.value, nil
+		}
+
+		value, err := resolve(p)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.sweep(now)
+		c.entries[key] = fieldCacheEntry{value: value, expires: now.Add(c.ttl)}
+		c.mu.Unlock()
+		return value, nil
+	}
+}
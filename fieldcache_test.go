@@ -0,0 +1,197 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type CachedHost struct {
+	calls int
+}
+
+func (h *CachedHost) Expensive(input Tagged) (string, error) {
+	h.calls++
+	return "result " + input.Field, nil
+}
+
+// fakeClock is a settable time source for fieldCache.now
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestFieldCache(t *testing.T) {
+	host := &CachedHost{}
+	b := NewSchemaBuilder().WithFieldCache("expensive", time.Minute)
+	clock := &fakeClock{now: time.Now()}
+	b.fieldCaches["expensive"].now = clock.Now
+	schema, err := b.WithQuery(host).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	run := func(query string) {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: query,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+	}
+
+	run(`{ expensive(field: "a") }`)
+	run(`{ expensive(field: "a") }`)
+	if host.calls != 1 {
+		t.Fatalf("expected 1 call for identical arguments, got %d", host.calls)
+	}
+
+	run(`{ expensive(field: "b") }`)
+	if host.calls != 2 {
+		t.Fatalf("expected 2 calls for different arguments, got %d", host.calls)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	run(`{ expensive(field: "a") }`)
+	if host.calls != 3 {
+		t.Fatalf("expected 3 calls after expiry, got %d", host.calls)
+	}
+}
+
+type CachedItem struct {
+	ID string `gql:"id"`
+}
+
+func (i *CachedItem) Label() (string, error) {
+	return "label-" + i.ID, nil
+}
+
+type CachedItemsHost struct{}
+
+func (h *CachedItemsHost) Items() ([]*CachedItem, error) {
+	return []*CachedItem{{ID: "a"}, {ID: "b"}}, nil
+}
+
+func TestFieldCacheKeyedBySource(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithFieldCache("label", time.Minute).
+		WithQuery(&CachedItemsHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ items { id label } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": "a", "label": "label-a"},
+			map[string]interface{}{"id": "b", "label": "label-b"},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestFieldCacheKeyedBySourceValue(t *testing.T) {
+	cache := newFieldCache(time.Minute)
+	calls := 0
+	resolve := cache.wrap(func(p graphql.ResolveParams) (interface{}, error) {
+		calls++
+		return "label-" + p.Source.(*CachedItem).ID, nil
+	})
+
+	// Equal sources at different addresses share entries, distinct sources
+	// don't, wherever they are allocated
+	for _, source := range []*CachedItem{{ID: "a"}, {ID: "a"}, {ID: "b"}} {
+		value, err := resolve(graphql.ResolveParams{Source: source, Info: graphql.ResolveInfo{FieldName: "label"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if value != "label-"+source.ID {
+			t.Fatalf("expected label-%s, got %v", source.ID, value)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestFieldCacheEviction(t *testing.T) {
+	cache := newFieldCache(time.Minute)
+	clock := &fakeClock{now: time.Now()}
+	cache.now = clock.Now
+	resolve := cache.wrap(func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Args["n"], nil
+	})
+
+	for i := 0; i < 3; i++ {
+		resolve(graphql.ResolveParams{Args: map[string]interface{}{"n": i}})
+	}
+	clock.now = clock.now.Add(2 * time.Minute)
+	resolve(graphql.ResolveParams{Args: map[string]interface{}{"n": 3}})
+
+	if len(cache.entries) != 1 {
+		t.Fatalf("expected expired entries to be evicted, got %d entries", len(cache.entries))
+	}
+}
+
+type cacheUserKey struct{}
+
+func TestFieldCacheRunsContextMiddlewares(t *testing.T) {
+	host := &CachedHost{}
+	schema, err := NewSchemaBuilder().
+		WithFieldCache("expensive", time.Minute).
+		WithContextMiddleware(func(ctx context.Context, info graphql.ResolveInfo) (context.Context, error) {
+			if ctx.Value(cacheUserKey{}) == nil {
+				return nil, errors.New("unauthenticated")
+			}
+			return ctx, nil
+		}).
+		WithQuery(host).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	run := func(ctx context.Context) *graphql.Result {
+		return graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: `{ expensive(field: "secret") }`,
+			Context:       ctx,
+		})
+	}
+
+	if result := run(context.WithValue(context.Background(), cacheUserKey{}, "ada")); result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	// A denied request is not served the cached result
+	result := run(context.Background())
+	if len(result.Errors) != 1 || result.Errors[0].Message != "unauthenticated" {
+		t.Fatalf("expected an unauthenticated error, got %v", result.Errors)
+	}
+	if data := result.Data.(map[string]interface{}); data["expensive"] != nil {
+		t.Fatalf("expected no cached result, got %v", data["expensive"])
+	}
+	if host.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", host.calls)
+	}
+}
@@ -0,0 +1,59 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WithFieldTimeout bounds the resolution of the named field to timeout. The
+// resolver runs with a context derived with that deadline and the field fails
+// with a timeout error once it is exceeded.
+func (b *SchemaBuilder) WithFieldTimeout(field string, timeout time.Duration) *SchemaBuilder {
+	b.fieldTimeouts[field] = timeout
+	return b
+}
+
+type fieldResult struct {
+	value interface{}
+	err   error
+}
+
+// withTimeout runs resolve with a context canceled after timeout. Resolvers
+// ignoring the context keep running in the background until they return.
+// Panics of resolve are recovered in its goroutine and returned as errors,
+// as graphql-go only recovers panics of its own goroutine.
+func withTimeout(fieldName string, timeout time.Duration, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		parent := p.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		p.Context = ctx
+
+		done := make(chan fieldResult, 1)
+		go func() {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					done <- fieldResult{err: fmt.Errorf("Panic resolving %s: %v", fieldName, recovered)}
+				}
+			}()
+			value, err := resolve(p)
+			done <- fieldResult{value: value, err: err}
+		}()
+
+		select {
+		case result := <-done:
+			return result.value, result.err
+		case <-ctx.Done():
+			if err := parent.Err(); err != nil {
+				return nil, fmt.Errorf("Field %s canceled: %w", fieldName, err)
+			}
+			return nil, fmt.Errorf("Field %s timed out after %s", fieldName, timeout)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type SlowHost struct{}
+
+func (h *SlowHost) Slow(ctx context.Context) (string, error) {
+	select {
+	case <-time.After(time.Second):
+		return "slow", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (h *SlowHost) Fast(ctx context.Context) (string, error) {
+	return "fast", nil
+}
+
+func TestFieldTimeout(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithFieldTimeout("slow", 20*time.Millisecond).
+		WithFieldTimeout("fast", time.Second).
+		WithQuery(&SlowHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	start := time.Now()
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ slow fast }`,
+		Context:       context.Background(),
+	})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the slow field to be cut short, took %s", elapsed)
+	}
+
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "slow timed out") {
+		t.Fatalf("expected a timeout error for slow, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"slow": nil, "fast": "fast"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type PanickingSlowHost struct{}
+
+func (h *PanickingSlowHost) Boom(ctx context.Context) (string, error) {
+	panic("boom")
+}
+
+func TestFieldTimeoutRecoversPanics(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithFieldTimeout("boom", time.Second).
+		WithQuery(&PanickingSlowHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ boom }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "Panic resolving boom: boom") {
+		t.Fatalf("expected a panic error for boom, got %v", result.Errors)
+	}
+}
+
+func TestFieldTimeoutParentCanceled(t *testing.T) {
+	idle := func(p graphql.ResolveParams) (interface{}, error) {
+		// Ignores its context, so only the timeout wrapper can cut it short
+		time.Sleep(200 * time.Millisecond)
+		return "idle", nil
+	}
+	resolve := withTimeout("idle", time.Second, idle)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := resolve(graphql.ResolveParams{Context: ctx})
+	if err == nil || !strings.Contains(err.Error(), "Field idle canceled") {
+		t.Fatalf("expected a cancellation error for idle, got %v", err)
+	}
+}
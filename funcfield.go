@@ -0,0 +1,51 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// errNilFuncField reports a func-typed resolver field without a func value
+var errNilFuncField = errors.New("resolver function is nil")
+
+// funcFieldAsGraphqlField creates a field resolved by calling the func stored
+// in the struct field of the source (or the registered root instance)
+func (b *SchemaBuilder) funcFieldAsGraphqlField(definition reflect.Type, field reflect.StructField, fieldName string) (*graphql.Field, error) {
+	// The signature is inspected on a zero func, the actual func is read per source
+	resolveInfo, err := b.newFuncResolveInfo(reflect.Zero(field.Type))
+	if err != nil {
+		return nil, fmt.Errorf("invalid resolver field %s.%s: %w", definition.Name(), field.Name, err)
+	}
+
+	boundInstance, isBound := b.rootInstances[definition]
+	if isBound {
+		// Root instances are known at build time, so a nil func is a registration error
+		source := reflect.Indirect(reflect.ValueOf(boundInstance))
+		if source.IsValid() && source.FieldByIndex(field.Index).IsNil() {
+			return nil, fmt.Errorf("%w: %s.%s (field %s)", errNilFuncField, definition.Name(), field.Name, fieldName)
+		}
+	}
+
+	resolveInfo.funcFrom = func(p graphql.ResolveParams) (reflect.Value, error) {
+		source := reflect.ValueOf(p.Source)
+		if isBound {
+			source = reflect.ValueOf(boundInstance)
+		}
+		source = reflect.Indirect(source)
+		if !source.IsValid() {
+			return reflect.Value{}, nil
+		}
+
+		fn := source.FieldByIndex(field.Index)
+		if fn.IsNil() {
+			return reflect.Value{}, fmt.Errorf("resolver field %s.%s is nil", definition.Name(), field.Name)
+		}
+		return fn, nil
+	}
+
+	// The field is built like resolver methods, sharing their output handling
+	return b.resolverAsGraphqlField(fieldName, resolveInfo)
+}
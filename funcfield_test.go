@@ -0,0 +1,144 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type FuncFieldUser struct {
+	ID   string `gql:"id"`
+	Name string `gql:"name"`
+}
+
+type FuncFieldInput struct {
+	ID string `gql:"id,nonNull"`
+}
+
+type FuncFieldQuery struct {
+	GetUser  func(ctx context.Context, input FuncFieldInput) (*FuncFieldUser, error)
+	Count    func() (int, error) `gql:"userCount"`
+	Callback func(string)
+	Hidden   func() (int, error) `gql:"-"`
+}
+
+func TestFuncFieldResolvers(t *testing.T) {
+	users := map[string]string{"1": "john", "2": "jane"}
+
+	query := &FuncFieldQuery{
+		GetUser: func(ctx context.Context, input FuncFieldInput) (*FuncFieldUser, error) {
+			return &FuncFieldUser{ID: input.ID, Name: users[input.ID]}, nil
+		},
+		Count: func() (int, error) {
+			return len(users), nil
+		},
+	}
+
+	schema, err := NewSchemaBuilder().WithQuery(query).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.QueryType().Fields()
+	for _, name := range []string{"callback", "hidden"} {
+		if _, ok := fields[name]; ok {
+			t.Errorf("expected %s not to be exposed", name)
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ getUser(id: "2") { id name } userCount }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"getUser":   map[string]interface{}{"id": "2", "name": "jane"},
+		"userCount": 2,
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type InvalidFuncFieldQuery struct {
+	Broken func(a, b FuncFieldInput) (int, error) `gql:"broken"`
+}
+
+func TestInvalidFuncFieldResolver(t *testing.T) {
+	_, err := NewSchemaBuilder().WithQuery(&InvalidFuncFieldQuery{}).BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "InvalidFuncFieldQuery.Broken") {
+		t.Fatalf("expected error naming the resolver field, got %v", err)
+	}
+}
+
+func TestNilFuncFieldResolver(t *testing.T) {
+	query := &FuncFieldQuery{
+		Count: func() (int, error) {
+			return 0, nil
+		},
+	}
+
+	_, err := NewSchemaBuilder().WithQuery(query).BuildSchema()
+	if err == nil {
+		t.Fatalf("expected error for nil resolver function, got nil")
+	}
+
+	for _, expected := range []string{"resolver function is nil", "FuncFieldQuery.GetUser", "getUser"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %q", expected, err.Error())
+		}
+	}
+}
+
+type FuncFieldOutputQuery struct {
+	Temperature func() (Kelvin, error)  `gql:"temperature"`
+	Total       func() (int64, error)   `gql:"total"`
+	Name        func() (*string, error) `gql:"name,nonNull"`
+}
+
+func TestFuncFieldOutputs(t *testing.T) {
+	query := &FuncFieldOutputQuery{
+		Temperature: func() (Kelvin, error) { return 21.5, nil },
+		Total:       func() (int64, error) { return 1 << 40, nil },
+		Name:        func() (*string, error) { return nil, nil },
+	}
+
+	schema, err := NewSchemaBuilder().WithQuery(query).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ temperature }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+	if expected := map[string]interface{}{"temperature": 21.5}; !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+
+	cases := map[string]string{
+		`{ total }`: "out of the 32-bit Int range",
+		`{ name }`:  "non-null field",
+	}
+	for query, message := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: query,
+			Context:       context.Background(),
+		})
+		if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, message) {
+			t.Fatalf("%s: expected an error containing %q, got %v", query, message, result.Errors)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package gql
+
+import (
+	"encoding/json"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Handler serves a GraphQL schema over HTTP
+type Handler struct {
+	schema        *graphql.Schema
+	graphiQL      bool
+	recover       bool
+	marshal       JSONMarshaler
+	maxUploadSize int64
+	corsOrigins   []string
+}
+
+// JSONMarshaler encodes a value as JSON, such as json.Marshal
+type JSONMarshaler func(v interface{}) ([]byte, error)
+
+// HandlerOption configures a Handler
+type HandlerOption func(*Handler)
+
+// WithGraphiQL enables serving the GraphiQL IDE to browser requests
+func WithGraphiQL(enabled bool) HandlerOption {
+	return func(h *Handler) {
+		h.graphiQL = enabled
+	}
+}
+
+// WithRecover enables converting panics raised while serving a request, such
+// as in parsing, validation or response encoding, into a 500 JSON error
+// response. Panics are logged along with their stack trace.
+func WithRecover(enabled bool) HandlerOption {
+	return func(h *Handler) {
+		h.recover = enabled
+	}
+}
+
+// WithJSONMarshaler sets the marshaler encoding responses, json.Marshal by
+// default
+func WithJSONMarshaler(marshal JSONMarshaler) HandlerOption {
+	return func(h *Handler) {
+		h.marshal = marshal
+	}
+}
+
+// NewHandler creates an http.Handler executing requests against schema
+func NewHandler(schema *graphql.Schema, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		schema:        schema,
+		marshal:       json.Marshal,
+		maxUploadSize: DefaultMaxUploadSize,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// RequestOptions is the body of a GraphQL HTTP request
+type RequestOptions struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.recover {
+		defer recoverPanic(w)
+	}
+
+	if h.setCORSHeaders(w, r) {
+		return
+	}
+
+	if h.graphiQL && r.Method == http.MethodGet && acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(graphiQLPage))
+		return
+	}
+
+	var opts *RequestOptions
+	var err error
+	if isMultipartRequest(r) {
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+		var files []multipart.File
+		defer removeMultipartFiles(r)
+		opts, files, err = parseMultipartOptions(r)
+		defer closeFiles(files)
+	} else {
+		opts, err = parseRequestOptions(r)
+	}
+	if err != nil {
+		status := http.StatusBadRequest
+		if isRequestTooLarge(err) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *h.schema,
+		RequestString:  opts.Query,
+		VariableValues: opts.Variables,
+		OperationName:  opts.OperationName,
+		Context:        WithMemo(r.Context()),
+	})
+
+	// Encode before writing so that a failing encoding doesn't leave a partial response
+	body, err := h.marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(body)
+}
+
+// recoverPanic logs a recovered panic and responds with a JSON error
+func recoverPanic(w http.ResponseWriter) {
+	if err := recover(); err != nil {
+		log.Printf("gql: panic serving request: %v\n%s", err, debug.Stack())
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": []map[string]interface{}{{"message": "internal server error"}},
+		})
+	}
+}
+
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+func parseRequestOptions(r *http.Request) (*RequestOptions, error) {
+	opts := &RequestOptions{}
+
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		opts.Query = query.Get("query")
+		opts.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &opts.Variables); err != nil {
+				return nil, err
+			}
+		}
+		return opts, nil
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(opts); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// graphiQLPage loads pinned UMD builds of GraphiQL 3 and React 18, later
+// releases no longer ship UMD builds
+const graphiQLPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>GraphiQL</title>
+  <style>body { height: 100%; margin: 0; width: 100%; overflow: hidden; } #graphiql { height: 100vh; }</style>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3.0.0/graphiql.min.css" crossorigin="anonymous" />
+  <script src="https://unpkg.com/react@18.3.1/umd/react.production.min.js" crossorigin="anonymous"></script>
+  <script src="https://unpkg.com/react-dom@18.3.1/umd/react-dom.production.min.js" crossorigin="anonymous"></script>
+  <script src="https://unpkg.com/graphiql@3.0.0/graphiql.min.js" crossorigin="anonymous"></script>
+</head>
+<body>
+  <div id="graphiql">Loading...</div>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: window.location.href });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(React.createElement(GraphiQL, { fetcher: fetcher }));
+  </script>
+</body>
+</html>
+`
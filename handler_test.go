@@ -0,0 +1,142 @@
+package gql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type HandlerQuery struct{}
+
+func (q *HandlerQuery) Hello() (string, error) {
+	return "world", nil
+}
+
+func newTestHandler(t *testing.T, opts ...HandlerOption) *Handler {
+	schema, err := NewSchemaBuilder().WithQuery(&HandlerQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return NewHandler(schema, opts...)
+}
+
+func TestHandlerGraphiQL(t *testing.T) {
+	h := newTestHandler(t, WithGraphiQL(true))
+
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Fatalf("expected html content type, got %s", contentType)
+	}
+	if !strings.Contains(rec.Body.String(), "graphiql") {
+		t.Fatalf("expected GraphiQL page, got %s", rec.Body.String())
+	}
+}
+
+func TestHandlerJSON(t *testing.T) {
+	h := newTestHandler(t, WithGraphiQL(true))
+
+	cases := []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/graphql?query={hello}", nil),
+		httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query":"{ hello }"}`)),
+	}
+
+	for _, req := range cases {
+		req.Header.Set("Accept", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+			t.Fatalf("expected json content type, got %s", contentType)
+		}
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("expected json body, got %v", err)
+		}
+
+		expected := map[string]interface{}{"hello": "world"}
+		if !reflect.DeepEqual(body["data"], expected) {
+			t.Fatalf("expected %v, got %v", expected, body["data"])
+		}
+	}
+}
+
+type panickingValue struct{}
+
+func (panickingValue) MarshalJSON() ([]byte, error) {
+	panic("marshal failure")
+}
+
+type PanicQuery struct{}
+
+func (q *PanicQuery) Broken() (panickingValue, error) {
+	return panickingValue{}, nil
+}
+
+func TestHandlerRecover(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	// The scalar passes the value through, so the panic happens while encoding the response
+	passthrough := graphql.NewScalar(graphql.ScalarConfig{
+		Name:      "Passthrough",
+		Serialize: func(value interface{}) interface{} { return value },
+	})
+	b := NewSchemaBuilder()
+	b.RegisterCustomType(reflect.TypeOf(panickingValue{}), passthrough)
+	schema, err := b.WithQuery(&PanicQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	h := NewHandler(schema, WithRecover(true))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query":"{ broken }"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", rec.Code)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expected json body, got %v: %s", err, rec.Body.String())
+	}
+	if _, ok := body["errors"]; !ok {
+		t.Fatalf("expected errors in body, got %v", body)
+	}
+}
+
+func TestHandlerJSONMarshaler(t *testing.T) {
+	calls := 0
+	marshal := func(v interface{}) ([]byte, error) {
+		calls++
+		return json.MarshalIndent(v, "", "  ")
+	}
+	h := newTestHandler(t, WithJSONMarshaler(marshal))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query":"{ hello }"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if calls != 1 {
+		t.Fatalf("expected the marshaler to be called once, got %d", calls)
+	}
+	expected := "{\n  \"data\": {\n    \"hello\": \"world\"\n  }\n}"
+	if body := rec.Body.String(); body != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+}
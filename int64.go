@@ -0,0 +1,116 @@
+package gql
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// WithInt64AsScalar maps int64 and uint64 to the Long scalar, serialized as
+// 64-bit integers, instead of the 32-bit GraphQL Int. Otherwise values beyond
+// the Int range fail their field rather than resolving to null.
+func (b *SchemaBuilder) WithInt64AsScalar(enabled bool) *SchemaBuilder {
+	b.int64Scalar = enabled
+	return b
+}
+
+// isLongType reports whether t maps to the Long scalar
+func (b *SchemaBuilder) isLongType(t reflect.Type) bool {
+	return b.int64Scalar && (t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64)
+}
+
+// Long is a scalar for 64-bit integers. Variables may also pass them as
+// strings, as JSON numbers lose precision beyond 2^53.
+var Long = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Long",
+	Description: "Long scalar type (64-bit integer)",
+	Serialize:   coerceLong,
+	ParseValue:  coerceLong,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch v := valueAST.(type) {
+		case *ast.IntValue:
+			return coerceLong(v.Value)
+		case *ast.StringValue:
+			return coerceLong(v.Value)
+		}
+		return nil
+	},
+})
+
+func coerceLong(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	case float64:
+		if v != math.Trunc(v) {
+			return nil
+		}
+		return int64(v)
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil
+		}
+		return int64(v.Uint())
+	}
+	return nil
+}
+
+// guardIntRange wraps the resolver of an Int field whose Go type t may hold
+// values beyond the 32-bit range of Int, failing the field for such values
+func guardIntRange(field *graphql.Field, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+	default:
+		return
+	}
+	if graphql.GetNamed(field.Type) != graphql.Int {
+		return
+	}
+
+	resolve := field.Resolve
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	field.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := resolve(p)
+		if err != nil || value == nil {
+			return value, err
+		}
+
+		v := reflect.ValueOf(value)
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return value, nil
+			}
+			v = v.Elem()
+		}
+		inRange := true
+		switch v.Kind() {
+		case reflect.Int, reflect.Int64:
+			inRange = v.Int() >= math.MinInt32 && v.Int() <= math.MaxInt32
+		case reflect.Uint, reflect.Uint32, reflect.Uint64:
+			inRange = v.Uint() <= math.MaxInt32
+		}
+		if !inRange {
+			return nil, fmt.Errorf("%v is out of the 32-bit Int range, see WithInt64AsScalar", value)
+		}
+		return value, nil
+	}
+}
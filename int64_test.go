@@ -0,0 +1,86 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Counter struct {
+	Hits  int64 `gql:"hits"`
+	Small int64 `gql:"small"`
+}
+
+type CounterInput struct {
+	Offset int64 `gql:"offset"`
+}
+
+type CounterHost struct{}
+
+func (h *CounterHost) Counter() (*Counter, error) {
+	return &Counter{Hits: 1 << 40, Small: 42}, nil
+}
+
+func (h *CounterHost) Total(input CounterInput) (int64, error) {
+	return 1<<40 + input.Offset, nil
+}
+
+func TestInt64OutOfIntRange(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&CounterHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ counter { hits small } total(offset: 1) }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 2 {
+		t.Fatalf("expected 2 out of range errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"counter": map[string]interface{}{"hits": nil, "small": 42},
+		"total":   nil,
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestWithInt64AsScalar(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithInt64AsScalar(true).WithQuery(&CounterHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.QueryType().Fields()
+	if fieldType := fields["total"].Type.String(); fieldType != "Long" {
+		t.Fatalf("expected Long, got %s", fieldType)
+	}
+	if argType := fields["total"].Args[0].Type.String(); argType != "Long" {
+		t.Fatalf("expected Long argument, got %s", argType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($offset: Long) { counter { hits small } literal: total(offset: 1099511627776) variable: total(offset: $offset) }`,
+		VariableValues: map[string]interface{}{"offset": "-1099511627776"},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"counter":  map[string]interface{}{"hits": int64(1 << 40), "small": int64(42)},
+		"literal":  int64(1 << 41),
+		"variable": int64(0),
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
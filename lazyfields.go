@@ -0,0 +1,65 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WithLazyFields defers building the fields of object types, other than the
+// roots, to a FieldsThunk. Types are registered before their fields are
+// built, so large and cyclic type graphs are built breadth first instead of
+// by deep recursion.
+func (b *SchemaBuilder) WithLazyFields(enabled bool) *SchemaBuilder {
+	b.lazyFields = enabled
+	return b
+}
+
+// lazyObject registers an object type for realDefinition whose fields are
+// built from definition when the thunk is first called
+func (b *SchemaBuilder) lazyObject(definition, realDefinition reflect.Type) *graphql.Object {
+	name := b.objectTypeName(realDefinition)
+	object := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name,
+		Description: b.descriptions[realDefinition.Name()],
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			if fields, ok := b.fieldsCache[realDefinition]; ok {
+				return fields
+			}
+
+			b.lazyBuilding[realDefinition] = true
+			defer delete(b.lazyBuilding, realDefinition)
+			if _, err := b.TypeAsGraphqlField(definition); err != nil {
+				b.lazyErrors = append(b.lazyErrors, fmt.Errorf("failed to build %s type: %w", name, err))
+				return graphql.Fields{}
+			}
+			return b.fieldsCache[realDefinition]
+		}),
+	})
+	b.typeRegistry[realDefinition] = object
+	return object
+}
+
+// buildLazyObjects calls the field thunks of the registered object types,
+// including the ones registered meanwhile, so that all argument types are
+// known and build errors are reported before the schema is created
+func (b *SchemaBuilder) buildLazyObjects() error {
+	built := map[reflect.Type]bool{}
+	for len(built) < len(b.typeRegistry) {
+		for definition, graphqlType := range b.typeRegistry {
+			if built[definition] {
+				continue
+			}
+			built[definition] = true
+			if object, ok := graphqlType.(*graphql.Object); ok {
+				object.Fields()
+			}
+		}
+	}
+
+	if len(b.lazyErrors) > 0 {
+		return b.lazyErrors[0]
+	}
+	return nil
+}
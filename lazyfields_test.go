@@ -0,0 +1,100 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type LazyAuthor struct {
+	Name  string      `gql:"name"`
+	Posts []*LazyPost `gql:"posts"`
+}
+
+type LazyPost struct {
+	Title    string         `gql:"title"`
+	Author   *LazyAuthor    `gql:"author"`
+	Comments []*LazyComment `gql:"comments"`
+}
+
+type LazyComment struct {
+	Body    string        `gql:"body"`
+	Post    *LazyPost     `gql:"post"`
+	Replies []LazyComment `gql:"replies"`
+}
+
+type LazyHost struct{}
+
+func (h *LazyHost) Post() (*LazyPost, error) {
+	author := &LazyAuthor{Name: "ada"}
+	post := &LazyPost{Title: "notes", Author: author}
+	post.Comments = []*LazyComment{{Body: "nice", Post: post}}
+	author.Posts = []*LazyPost{post}
+	return post, nil
+}
+
+func TestWithLazyFields(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithLazyFields(true).WithQuery(&LazyHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ post { title author { name posts { title } } comments { body post { title } replies { body } } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"post": map[string]interface{}{
+			"title": "notes",
+			"author": map[string]interface{}{
+				"name":  "ada",
+				"posts": []interface{}{map[string]interface{}{"title": "notes"}},
+			},
+			"comments": []interface{}{map[string]interface{}{
+				"body":    "nice",
+				"post":    map[string]interface{}{"title": "notes"},
+				"replies": []interface{}{},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type LazyBrokenNode struct {
+	Callback chan int `gql:"callback"`
+}
+
+type LazyBrokenHost struct{}
+
+func (h *LazyBrokenHost) Node() (*LazyBrokenNode, error) {
+	return &LazyBrokenNode{}, nil
+}
+
+func TestWithLazyFieldsError(t *testing.T) {
+	_, err := NewSchemaBuilder().WithLazyFields(true).WithQuery(&LazyBrokenHost{}).BuildSchema()
+	if err == nil {
+		t.Fatalf("expected error for unsupported lazy field type")
+	}
+}
+
+func BenchmarkBuildSchema(b *testing.B) {
+	for _, lazy := range []bool{false, true} {
+		b.Run(fmt.Sprintf("lazy=%v", lazy), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := NewSchemaBuilder().WithLazyFields(lazy).WithQuery(&LazyHost{}).BuildSchema(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package gql
+
+import (
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Logger receives printf style build diagnostics, such as log.Printf
+type Logger func(format string, args ...interface{})
+
+// WithLogger logs the types and fields constructed while building the schema,
+// tracing where a large build slows down or which type raised an error. The
+// builder doesn't log by default.
+func (b *SchemaBuilder) WithLogger(logger Logger) *SchemaBuilder {
+	b.logger = logger
+	return b
+}
+
+// logf logs a build diagnostic when a logger is set
+func (b *SchemaBuilder) logf(format string, args ...interface{}) {
+	if b.logger != nil {
+		b.logger(format, args...)
+	}
+}
+
+// sortedFieldNames returns the names of fields in order, for stable logs
+func sortedFieldNames(fields graphql.Fields) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
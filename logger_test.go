@@ -0,0 +1,41 @@
+package gql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	logs := []string{}
+	logger := func(format string, args ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+
+	_, err := NewSchemaBuilder().WithLogger(logger).WithQuery(&NestedInputHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	output := strings.Join(logs, "\n")
+	for _, expected := range []string{
+		"gql: building object NestedInputHost from gql.NestedInputHost",
+		"gql: building input AddressInput from gql.AddressInput",
+		"gql: built field NestedInputHost.createCustomer: String",
+		"gql: built schema config with 1 object types in",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected logs to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestWithoutLogger(t *testing.T) {
+	b := NewSchemaBuilder()
+	if _, err := b.WithQuery(&NestedInputHost{}).BuildSchema(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if b.logger != nil {
+		t.Fatalf("expected no logger by default")
+	}
+}
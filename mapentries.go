@@ -0,0 +1,100 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+// MapEntriesField is the field listing the entries of a map object
+const MapEntriesField = "entries"
+
+// mapEntry is the source of a map entry object
+type mapEntry struct {
+	key   string
+	value interface{}
+}
+
+// mapAsGraphqlField maps map[string]T, whose keys aren't known at schema time,
+// to an object listing its entries sorted by key, e.g. map[string]int maps to
+//
+//	type IntMap {
+//		entries: [IntEntry!]!
+//	}
+//
+//	type IntEntry {
+//		key: String!
+//		value: Int
+//	}
+func (b *SchemaBuilder) mapAsGraphqlField(definition reflect.Type) (*graphql.Field, error) {
+	if definition.Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("map types are only supported with string keys, got %s. Use gql:\"-\" tag to exclude map fields", definition)
+	}
+
+	valueField, err := b.TypeAsGraphqlField(definition.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	valueName := graphql.GetNamed(valueField.Type).(graphql.Type).Name()
+	if _, isList := nullableType(valueField.Type).(*graphql.List); isList {
+		valueName += "List"
+	}
+	if mapType, ok := b.mapTypes[valueName]; ok {
+		return &graphql.Field{Type: mapType}, nil
+	}
+
+	entryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: valueName + "Entry",
+		Fields: graphql.Fields{
+			"key": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(mapEntry).key, nil
+				},
+			},
+			"value": &graphql.Field{
+				Type: valueField.Type,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(mapEntry).value, nil
+				},
+			},
+		},
+	})
+
+	mapType := graphql.NewObject(graphql.ObjectConfig{
+		Name: valueName + "Map",
+		Fields: graphql.Fields{
+			MapEntriesField: &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(entryType))),
+				Resolve: resolveMapEntries,
+			},
+		},
+	})
+	b.mapTypes[valueName] = mapType
+
+	return &graphql.Field{Type: mapType}, nil
+}
+
+// resolveMapEntries lists the entries of the source map sorted by key
+func resolveMapEntries(p graphql.ResolveParams) (interface{}, error) {
+	source := reflect.ValueOf(p.Source)
+	for source.Kind() == reflect.Ptr {
+		source = source.Elem()
+	}
+	if source.Kind() != reflect.Map {
+		return nil, fmt.Errorf("expected a map source, got %T", p.Source)
+	}
+
+	entries := make([]mapEntry, 0, source.Len())
+	iter := source.MapRange()
+	for iter.Next() {
+		entries = append(entries, mapEntry{key: iter.Key().String(), value: valueInterface(iter.Value())})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries, nil
+}
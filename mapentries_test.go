@@ -0,0 +1,69 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Inventory struct {
+	Name   string         `gql:"name"`
+	Counts map[string]int `gql:"counts"`
+}
+
+type MapHost struct{}
+
+func (h *MapHost) Scores() (map[string]int, error) {
+	return map[string]int{"bob": 3, "ada": 5}, nil
+}
+
+func (h *MapHost) Inventory() (*Inventory, error) {
+	return &Inventory{Name: "store", Counts: map[string]int{"pens": 10}}, nil
+}
+
+func TestMapEntries(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&MapHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if fieldType := schema.QueryType().Fields()["scores"].Type.String(); fieldType != "IntMap" {
+		t.Fatalf("expected IntMap, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ scores { entries { key value } } inventory { counts { entries { key value } } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"scores": map[string]interface{}{
+			"entries": []interface{}{
+				map[string]interface{}{"key": "ada", "value": 5},
+				map[string]interface{}{"key": "bob", "value": 3},
+			},
+		},
+		"inventory": map[string]interface{}{
+			"counts": map[string]interface{}{
+				"entries": []interface{}{
+					map[string]interface{}{"key": "pens", "value": 10},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestMapNonStringKeys(t *testing.T) {
+	if _, err := NewSchemaBuilder().TypeAsGraphqlField(reflect.TypeOf(map[int]string{})); err == nil {
+		t.Fatalf("expected error for non-string map keys")
+	}
+}
@@ -0,0 +1,56 @@
+package gql
+
+import (
+	"context"
+	"sync"
+)
+
+type memoContextKey struct{}
+
+type memoEntry struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// memoStore holds the memoized values of a single request
+type memoStore struct {
+	mu      sync.Mutex
+	entries map[interface{}]*memoEntry
+}
+
+// WithMemo returns a context carrying a memo store for Memoize, scoping the
+// memoized values to requests executed with it. Handler attaches one to each
+// request.
+func WithMemo(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(memoContextKey{}).(*memoStore); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, memoContextKey{}, &memoStore{entries: make(map[interface{}]*memoEntry)})
+}
+
+// Memoize returns the result of fn computed once per key within the request
+// of ctx, so that sibling field resolvers of a source can share expensive
+// derived data, e.g. keyed by the source ID. The key must be comparable.
+// Without a memo store in ctx, see WithMemo, fn is called every time.
+func Memoize[T any](ctx context.Context, key interface{}, fn func() (T, error)) (T, error) {
+	store, ok := ctx.Value(memoContextKey{}).(*memoStore)
+	if !ok {
+		return fn()
+	}
+
+	store.mu.Lock()
+	entry, ok := store.entries[key]
+	if !ok {
+		entry = &memoEntry{}
+		store.entries[key] = entry
+	}
+	store.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = fn()
+	})
+
+	value, _ := entry.value.(T)
+	return value, entry.err
+}
@@ -0,0 +1,89 @@
+package gql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type MemoStats struct {
+	Posts     int
+	Followers int
+}
+
+type MemoUser struct {
+	ID string `gql:"id"`
+
+	computations *int
+}
+
+func (u *MemoUser) stats(ctx context.Context) (MemoStats, error) {
+	return Memoize(ctx, "stats:"+u.ID, func() (MemoStats, error) {
+		*u.computations++
+		return MemoStats{Posts: 3, Followers: 7}, nil
+	})
+}
+
+func (u *MemoUser) PostCount(ctx context.Context) (int, error) {
+	stats, err := u.stats(ctx)
+	return stats.Posts, err
+}
+
+func (u *MemoUser) FollowerCount(ctx context.Context) (int, error) {
+	stats, err := u.stats(ctx)
+	return stats.Followers, err
+}
+
+type MemoHost struct {
+	computations int
+}
+
+func (h *MemoHost) User() (*MemoUser, error) {
+	return &MemoUser{ID: "u1", computations: &h.computations}, nil
+}
+
+func TestMemoize(t *testing.T) {
+	host := &MemoHost{}
+	schema, err := NewSchemaBuilder().WithQuery(host).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ user { postCount followerCount } }`,
+		Context:       WithMemo(context.Background()),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+	if host.computations != 1 {
+		t.Fatalf("expected 1 computation, got %d", host.computations)
+	}
+
+	// Each request has its own memo store
+	h := NewHandler(schema)
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ user { postCount followerCount } }"}`))
+	request.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(httptest.NewRecorder(), request)
+	if host.computations != 2 {
+		t.Fatalf("expected 2 computations, got %d", host.computations)
+	}
+}
+
+func TestMemoizeWithoutStore(t *testing.T) {
+	calls := 0
+	fn := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+	Memoize(context.Background(), "key", fn)
+	Memoize(context.Background(), "key", fn)
+	if calls != 2 {
+		t.Fatalf("expected 2 calls without a memo store, got %d", calls)
+	}
+}
@@ -0,0 +1,38 @@
+package gql
+
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// MetricsRecorder receives resolver-level measurements. Implementations
+// typically bridge them to a metrics system such as Prometheus, e.g. a
+// counter and a histogram labeled by field.
+type MetricsRecorder interface {
+	// ObserveResolver is called once per resolved field with the name of the
+	// parent type, the time spent in its resolver and the error it returned
+	ObserveResolver(typeName string, field string, duration time.Duration, err error)
+}
+
+// WithMetrics reports the resolution of every resolver built by the builder
+// to recorder
+func (b *SchemaBuilder) WithMetrics(recorder MetricsRecorder) *SchemaBuilder {
+	b.metrics = recorder
+	return b
+}
+
+// withMetrics wraps resolve to report its duration and error to recorder
+func withMetrics(fieldName string, recorder MetricsRecorder, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		typeName := ""
+		if p.Info.ParentType != nil {
+			typeName = p.Info.ParentType.Name()
+		}
+
+		start := time.Now()
+		value, err := resolve(p)
+		recorder.ObserveResolver(typeName, fieldName, time.Since(start), err)
+		return value, err
+	}
+}
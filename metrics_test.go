@@ -0,0 +1,77 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type observation struct {
+	typeName string
+	field    string
+	duration time.Duration
+	err      error
+}
+
+type testRecorder struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (r *testRecorder) ObserveResolver(typeName string, field string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observations = append(r.observations, observation{typeName, field, duration, err})
+}
+
+type MetricsHost struct{}
+
+func (h *MetricsHost) Sleepy() (string, error) {
+	time.Sleep(5 * time.Millisecond)
+	return "done", nil
+}
+
+func (h *MetricsHost) Failing() (string, error) {
+	return "", errors.New("boom")
+}
+
+func TestWithMetrics(t *testing.T) {
+	recorder := &testRecorder{}
+	schema, err := NewSchemaBuilder().WithMetrics(recorder).WithQuery(&MetricsHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ sleepy failing }`,
+		Context:       context.Background(),
+	})
+
+	counts := map[string]int{}
+	for _, o := range recorder.observations {
+		if o.typeName != "MetricsHost" {
+			t.Fatalf("expected parent type MetricsHost, got %s", o.typeName)
+		}
+		counts[o.field]++
+
+		switch o.field {
+		case "sleepy":
+			if o.duration < 5*time.Millisecond || o.err != nil {
+				t.Fatalf("expected sleepy to take at least 5ms without error, got %s %v", o.duration, o.err)
+			}
+		case "failing":
+			if o.err == nil || o.err.Error() != "boom" {
+				t.Fatalf("expected failing to report its error, got %v", o.err)
+			}
+		}
+	}
+
+	if counts["sleepy"] != 1 || counts["failing"] != 1 {
+		t.Fatalf("expected one observation per field, got %v", counts)
+	}
+}
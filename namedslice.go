@@ -0,0 +1,64 @@
+package gql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// NamedSliceItemsField is the field exposing the elements of a named slice object
+const NamedSliceItemsField = "items"
+
+// namedSliceAsGraphqlField maps a named slice type with resolver methods, such
+// as a UserList []*User with a TotalAge() (int, error) method, to an object
+// with an items field listing the elements next to the method fields. It
+// reports false for slices without resolver methods, which map to plain lists.
+func (b *SchemaBuilder) namedSliceAsGraphqlField(definition reflect.Type) (*graphql.Field, bool, error) {
+	if definition.Kind() != reflect.Slice || definition.Name() == "" {
+		return nil, false, nil
+	}
+
+	if existingType, ok := b.typeRegistry[definition]; ok {
+		return &graphql.Field{Type: existingType}, true, nil
+	}
+
+	resolveInfos := map[string]*ResolveInfo{}
+	for i := 0; i < definition.NumMethod(); i++ {
+		method := definition.Method(i)
+		resolveInfo, err := b.newResolveInfo(method.Func)
+		if err != nil {
+			continue
+		}
+		resolveInfos[b.fieldNamer(method.Name)] = resolveInfo
+	}
+
+	if len(resolveInfos) == 0 {
+		return nil, false, nil
+	}
+
+	itemsField, err := b.TypeAsGraphqlField(reflect.SliceOf(definition.Elem()))
+	if err != nil {
+		return nil, true, err
+	}
+	itemsField.Name = NamedSliceItemsField
+	itemsField.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source, nil
+	}
+
+	fields := graphql.Fields{NamedSliceItemsField: itemsField}
+	for fieldName, resolveInfo := range resolveInfos {
+		graphqlField, err := b.resolverAsGraphqlField(fieldName, resolveInfo)
+		if err != nil {
+			return nil, true, err
+		}
+		fields[fieldName] = graphqlField
+	}
+
+	graphqlType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   b.objectTypeName(definition),
+		Fields: fields,
+	})
+	b.typeRegistry[definition] = graphqlType
+
+	return &graphql.Field{Type: graphqlType}, true, nil
+}
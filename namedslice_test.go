@@ -0,0 +1,77 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type SliceUser struct {
+	Name string `gql:"name"`
+	Age  int    `gql:"age"`
+}
+
+type SliceUsers []*SliceUser
+
+type TeamMembers []*SliceUser
+
+func (m TeamMembers) TotalAge() (int, error) {
+	total := 0
+	for _, user := range m {
+		total += user.Age
+	}
+	return total, nil
+}
+
+type NamedSliceHost struct{}
+
+func (h *NamedSliceHost) Users() (SliceUsers, error) {
+	return SliceUsers{{Name: "alice", Age: 30}, {Name: "bob", Age: 40}}, nil
+}
+
+func (h *NamedSliceHost) Team() (TeamMembers, error) {
+	return TeamMembers{{Name: "alice", Age: 30}, {Name: "bob", Age: 40}}, nil
+}
+
+func TestNamedSlices(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&NamedSliceHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.QueryType().Fields()
+	if fieldType := fields["users"].Type.String(); fieldType != "[SliceUser]" {
+		t.Fatalf("expected [SliceUser], got %s", fieldType)
+	}
+	if fieldType := fields["team"].Type.String(); fieldType != "TeamMembers" {
+		t.Fatalf("expected TeamMembers, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ users { name } team { items { name } totalAge } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice"},
+			map[string]interface{}{"name": "bob"},
+		},
+		"team": map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"name": "alice"},
+				map[string]interface{}{"name": "bob"},
+			},
+			"totalAge": 70,
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
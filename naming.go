@@ -0,0 +1,47 @@
+package gql
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// FieldNamer derives a GraphQL field name from a Go method name
+type FieldNamer func(name string) string
+
+// LowerCamelCase converts a Go method name to lower camel case, treating a
+// leading acronym as a single word: GetUserByID -> getUserByID,
+// ID -> id, HTTPStatus -> httpStatus
+func LowerCamelCase(name string) string {
+	runes := []rune(name)
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		// Keep the last capital of a leading acronym when it starts the next word
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// typeArgumentName names the object of a generic wrapper after its type
+// argument t, dropping pointers and adding a List suffix per list level so
+// that wrappers of T and []T don't collide: *User -> User, []*User -> UserList.
+// Unnamed types are named after their kind, int -> Int.
+func typeArgumentName(t reflect.Type) string {
+	suffix := ""
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		if t.Kind() != reflect.Ptr {
+			suffix += "List"
+		}
+		t = t.Elem()
+	}
+	name := t.Name()
+	if name == "" {
+		name = t.Kind().String()
+	}
+	return strings.ToUpper(name[:1]) + name[1:] + suffix
+}
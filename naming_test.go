@@ -0,0 +1,133 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestLowerCamelCase(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{"GetUser", "getUser"},
+		{"GetUserByID", "getUserByID"},
+		{"ID", "id"},
+		{"HTTPStatus", "httpStatus"},
+		{"A", "a"},
+		{"getUser", "getUser"},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := LowerCamelCase(c.name); actual != c.expected {
+				t.Fatalf("expected %s, got %s", c.expected, actual)
+			}
+		})
+	}
+}
+
+type NamingHost struct{}
+
+func (h *NamingHost) GetUserByID() (string, error) {
+	return "user", nil
+}
+
+func TestWithFieldNamer(t *testing.T) {
+	cases := []struct {
+		builder  *SchemaBuilder
+		query    string
+		expected map[string]interface{}
+	}{
+		{
+			builder:  NewSchemaBuilder(),
+			query:    `{ getUserByID }`,
+			expected: map[string]interface{}{"getUserByID": "user"},
+		},
+		{
+			builder:  NewSchemaBuilder().WithFieldNamer(strings.ToLower),
+			query:    `{ getuserbyid }`,
+			expected: map[string]interface{}{"getuserbyid": "user"},
+		},
+	}
+
+	for _, c := range cases {
+		schema, err := c.builder.WithQuery(&NamingHost{}).BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: c.query,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		if !reflect.DeepEqual(result.Data, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, result.Data)
+		}
+	}
+}
+
+type NamedProfile struct {
+	FirstName string
+	LastName  string
+}
+
+type NamedProfileInput struct {
+	FirstName string
+	LastName  string
+}
+
+type NamedProfileHost struct{}
+
+func (h *NamedProfileHost) Profile(input NamedProfileInput) (*NamedProfile, error) {
+	return &NamedProfile{FirstName: input.FirstName, LastName: input.LastName}, nil
+}
+
+// snakeCase converts a Go name to snake case, e.g. FirstName -> first_name
+func snakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			sb.WriteRune('_')
+		}
+		sb.WriteString(strings.ToLower(string(r)))
+	}
+	return sb.String()
+}
+
+func TestFieldNamerOnExposedInputFields(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithFieldNamer(snakeCase).
+		WithExposeAllFields(true).
+		WithQuery(&NamedProfileHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ profile(first_name: "Ada", last_name: "Lovelace") { first_name last_name } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"profile": map[string]interface{}{"first_name": "Ada", "last_name": "Lovelace"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
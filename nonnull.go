@@ -0,0 +1,48 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// WithNullableResolvers keeps fields resolved by methods nullable, even when
+// their types map to non-null types such as non-null lists, so that resolvers
+// returning nil resolve to null instead of failing the non-null check
+func (b *SchemaBuilder) WithNullableResolvers(enabled bool) *SchemaBuilder {
+	b.nullableResolvers = enabled
+	return b
+}
+
+// guardNonNull wraps the resolver of a non-null field, failing with an error
+// naming the field when it resolves to nil without an error, rather than
+// graphql-go's generic non-null violation
+func guardNonNull(field *graphql.Field) {
+	nonNull, ok := field.Type.(*graphql.NonNull)
+	if !ok {
+		return
+	}
+
+	resolve := field.Resolve
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	field.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := resolve(p)
+		if err != nil || !isNilValue(value) {
+			return value, err
+		}
+		return nil, fmt.Errorf("non-null field %s.%s of type %s resolved to nil, return an error or make it nullable, see WithNullablePointers and WithNullableResolvers",
+			p.Info.ParentType.Name(), p.Info.FieldName, nonNull)
+	}
+}
+
+// isNilValue reports whether value is nil or a nil pointer
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
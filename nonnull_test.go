@@ -0,0 +1,147 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Owner struct {
+	Name string `gql:"name"`
+}
+
+type Draft struct {
+	Title string `gql:"title"`
+	Owner *Owner `gql:"owner,nonNull"`
+}
+
+type Token string
+
+type DraftHost struct{}
+
+func (h *DraftHost) Draft() (*Draft, error) {
+	return &Draft{Title: "draft"}, nil
+}
+
+func (h *DraftHost) Token() (*Token, error) {
+	return nil, nil
+}
+
+func newDraftSchemaBuilder() *SchemaBuilder {
+	b := NewSchemaBuilder()
+	b.RegisterCustomType(reflect.TypeOf(Token("")), graphql.NewNonNull(graphql.String))
+	return b
+}
+
+func TestNonNullFieldResolvingNil(t *testing.T) {
+	schema, err := newDraftSchemaBuilder().WithQuery(&DraftHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ draft { title owner { name } } }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %v", result.Errors)
+	}
+	expected := "non-null field Draft.owner of type Owner! resolved to nil"
+	if !strings.Contains(result.Errors[0].Message, expected) {
+		t.Fatalf("expected error containing %q, got %q", expected, result.Errors[0].Message)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ token }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %v", result.Errors)
+	}
+	expected = "non-null field DraftHost.token of type String! resolved to nil"
+	if !strings.Contains(result.Errors[0].Message, expected) {
+		t.Fatalf("expected error containing %q, got %q", expected, result.Errors[0].Message)
+	}
+}
+
+func TestWithNullableResolvers(t *testing.T) {
+	schema, err := newDraftSchemaBuilder().WithNullableResolvers(true).WithQuery(&DraftHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if fieldType := schema.QueryType().Fields()["token"].Type.String(); fieldType != "String" {
+		t.Fatalf("expected String, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ token }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"token": nil}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type Ticket struct {
+	ID          string `gql:"id"`
+	AssigneeRef *Owner `gql:"assignee,nonNull"`
+}
+
+func (t *Ticket) Assignee(ctx context.Context) (*Owner, error) {
+	if t.ID == "unassigned" {
+		return nil, nil
+	}
+	return &Owner{Name: "ada"}, nil
+}
+
+type TicketHost struct{}
+
+func (h *TicketHost) Tickets() ([]*Ticket, error) {
+	return []*Ticket{{ID: "t1"}, {ID: "unassigned"}}, nil
+}
+
+func TestNonNullFieldBackedByResolver(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&TicketHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if fieldType := schema.Type("Ticket").(*graphql.Object).Fields()["assignee"].Type.String(); fieldType != "Owner!" {
+		t.Fatalf("expected Owner!, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ tickets { id assignee { name } } }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %v", result.Errors)
+	}
+	expectedError := "non-null field Ticket.assignee of type Owner! resolved to nil"
+	if !strings.Contains(result.Errors[0].Message, expectedError) {
+		t.Fatalf("expected error containing %q, got %q", expectedError, result.Errors[0].Message)
+	}
+
+	expected := map[string]interface{}{
+		"tickets": []interface{}{
+			map[string]interface{}{"id": "t1", "assignee": map[string]interface{}{"name": "ada"}},
+			nil,
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
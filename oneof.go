@@ -0,0 +1,83 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// isOneOfInput reports whether t is marked as a oneOf input by a blank field
+// tagged with the oneOf modifier:
+//
+//	type UserBy struct {
+//		_     struct{} `gql:",oneOf"`
+//		ID    *string  `gql:"id"`
+//		Email *string  `gql:"email"`
+//	}
+func isOneOfInput(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag, err := ParseGqlTagFromField(&field); err == nil && tag.OneOf {
+			return true
+		}
+	}
+	return false
+}
+
+// markOneOf records inputObj, built from definition, as a oneOf input for
+// the builder's PrintSchema
+func (b *SchemaBuilder) markOneOf(definition reflect.Type, inputObj *graphql.InputObject) {
+	if isOneOfInput(definition) {
+		b.oneOfInputs[inputObj] = true
+	}
+}
+
+// checkOneOfFields ensures every field of a oneOf input can be left unset
+func checkOneOfFields(t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, err := ParseGqlTagFromField(&field)
+		if err != nil {
+			return err
+		}
+		if tag.FieldName == "" || tag.FieldName == "-" {
+			continue
+		}
+		if tag.IsNonNull() {
+			return fmt.Errorf("oneOf input %s field %s can't be nonNull", t, field.Name)
+		}
+		switch field.Type.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		default:
+			return fmt.Errorf("oneOf input %s field %s should be a pointer, got %s", t, field.Name, field.Type)
+		}
+	}
+	return nil
+}
+
+// validateOneOf ensures exactly one field of a oneOf input value is set
+func validateOneOf(value reflect.Value) error {
+	set := 0
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag, err := ParseGqlTagFromField(&field)
+		if err != nil {
+			return err
+		}
+		if tag.FieldName == "" || tag.FieldName == "-" {
+			continue
+		}
+		if !value.Field(i).IsNil() {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return fmt.Errorf("Invalid %s: exactly one field must be set, got %d", value.Type().Name(), set)
+	}
+	return nil
+}
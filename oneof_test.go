@@ -0,0 +1,130 @@
+package gql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type UserBy struct {
+	_     struct{} `gql:",oneOf"`
+	ID    *string  `gql:"id"`
+	Email *string  `gql:"email"`
+}
+
+type LookupInput struct {
+	By UserBy `gql:"by,nonNull"`
+}
+
+type OneOfHost struct{}
+
+func (h *OneOfHost) FindBy(input UserBy) (string, error) {
+	if input.ID != nil {
+		return "id " + *input.ID, nil
+	}
+	return "email " + *input.Email, nil
+}
+
+func (h *OneOfHost) Lookup(input LookupInput) (string, error) {
+	return h.FindBy(input.By)
+}
+
+func TestOneOfInput(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&OneOfHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := []struct {
+		query    string
+		expected string
+		err      string
+	}{
+		{query: `{ findBy(id: "1") }`, expected: "id 1"},
+		{query: `{ lookup(by: {email: "a@b.c"}) }`, expected: "email a@b.c"},
+		{query: `{ findBy }`, err: "exactly one field must be set, got 0"},
+		{query: `{ findBy(id: "1", email: "a@b.c") }`, err: "exactly one field must be set, got 2"},
+		{query: `{ lookup(by: {id: "1", email: "a@b.c"}) }`, err: "exactly one field must be set, got 2"},
+	}
+
+	for _, c := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: c.query,
+			Context:       context.Background(),
+		})
+		if c.err != "" {
+			if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, c.err) {
+				t.Errorf("%s: expected error %q, got %v", c.query, c.err, result.Errors)
+			}
+			continue
+		}
+		if result.Errors != nil {
+			t.Errorf("%s: expected no errors, got %v", c.query, result.Errors)
+			continue
+		}
+		data := result.Data.(map[string]interface{})
+		for _, value := range data {
+			if value != c.expected {
+				t.Errorf("%s: expected %q, got %v", c.query, c.expected, value)
+			}
+		}
+	}
+}
+
+type InvalidOneOf struct {
+	_  struct{} `gql:",oneOf"`
+	ID string   `gql:"id"`
+}
+
+type InvalidOneOfHost struct{}
+
+func (h *InvalidOneOfHost) FindBy(input InvalidOneOf) (string, error) {
+	return input.ID, nil
+}
+
+func TestOneOfInputNonPointerField(t *testing.T) {
+	_, err := NewSchemaBuilder().WithQuery(&InvalidOneOfHost{}).BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "should be a pointer") {
+		t.Fatalf("expected pointer field error, got %v", err)
+	}
+}
+
+type PointerOneOfHost struct{}
+
+func (h *PointerOneOfHost) FindBy(input *UserBy) (string, error) {
+	if input == nil {
+		return "nobody", nil
+	}
+	return (&OneOfHost{}).FindBy(*input)
+}
+
+func TestOneOfPointerInputOmitted(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&PointerOneOfHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ findBy }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "exactly one field must be set, got 0") {
+		t.Fatalf("expected a oneOf error, got %v", result.Errors)
+	}
+}
+
+func TestPrintSchemaOneOf(t *testing.T) {
+	b := NewSchemaBuilder()
+	schema, err := b.WithQuery(&OneOfHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if sdl := b.PrintSchema(schema); !strings.Contains(sdl, "input UserBy @oneOf {") {
+		t.Fatalf("expected a oneOf input, got %s", sdl)
+	}
+}
@@ -0,0 +1,29 @@
+package gql
+
+import "reflect"
+
+// Page is a page of items along with the total count of all items, a
+// lighter alternative to Relay connections. A Page[*User] maps to the
+// UserPage object with items and totalCount fields.
+type Page[T any] struct {
+	Items      []T `gql:"items,nonNull"`
+	TotalCount int `gql:"totalCount,nonNull"`
+}
+
+// WithPage wraps the items of a page and the total count of all items as the
+// result of a resolver:
+//
+//	func (q query) Users(args PageInput) (*gql.Page[*User], error) {
+//		return gql.WithPage(users[args.Offset:args.Offset+args.Limit], len(users))
+//	}
+func WithPage[T any](items []T, totalCount int) (*Page[T], error) {
+	if items == nil {
+		items = []T{}
+	}
+	return &Page[T]{Items: items, TotalCount: totalCount}, nil
+}
+
+// GraphQLTypeName names the page object after its item type
+func (Page[T]) GraphQLTypeName() string {
+	return typeArgumentName(reflect.TypeOf((*T)(nil)).Elem()) + "Page"
+}
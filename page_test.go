@@ -0,0 +1,77 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type PagedUser struct {
+	Name string `gql:"name"`
+}
+
+type UserPageInput struct {
+	Offset int `gql:"offset"`
+	Limit  int `gql:"limit"`
+}
+
+type PageQuery struct {
+	users []*PagedUser
+}
+
+func (q *PageQuery) Users(input UserPageInput) (*Page[*PagedUser], error) {
+	end := input.Offset + input.Limit
+	if end > len(q.users) {
+		end = len(q.users)
+	}
+	return WithPage(q.users[input.Offset:end], len(q.users))
+}
+
+func (q *PageQuery) Tags() (*Page[string], error) {
+	return WithPage([]string{"go"}, 1)
+}
+
+func TestPage(t *testing.T) {
+	query := &PageQuery{users: []*PagedUser{{Name: "ada"}, {Name: "bob"}, {Name: "cy"}}}
+	schema, err := NewSchemaBuilder().WithQuery(query).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	page, ok := schema.Type("PagedUserPage").(*graphql.Object)
+	if !ok {
+		t.Fatalf("expected PagedUserPage object, got %v", schema.Type("PagedUserPage"))
+	}
+	fields := page.Fields()
+	if fieldType := fields["items"].Type.String(); fieldType != "[PagedUser]!" {
+		t.Fatalf("expected [PagedUser]!, got %s", fieldType)
+	}
+	if fieldType := fields["totalCount"].Type.String(); fieldType != "Int!" {
+		t.Fatalf("expected Int!, got %s", fieldType)
+	}
+	if schema.Type("StringPage") == nil {
+		t.Fatalf("expected StringPage object")
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ users(offset: 1, limit: 5) { items { name } totalCount } tags { items totalCount } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"users": map[string]interface{}{
+			"items":      []interface{}{map[string]interface{}{"name": "bob"}, map[string]interface{}{"name": "cy"}},
+			"totalCount": 3,
+		},
+		"tags": map[string]interface{}{"items": []interface{}{"go"}, "totalCount": 1},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
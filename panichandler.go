@@ -0,0 +1,32 @@
+package gql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+)
+
+// PanicHandler is called with the value recovered from a panicking resolver
+// and the params of the field, such as for logging or alerting
+type PanicHandler func(recovered interface{}, p graphql.ResolveParams)
+
+// WithPanicHandler recovers panics of resolvers, calling handler before the
+// panic is returned as an error of the field
+func (b *SchemaBuilder) WithPanicHandler(handler PanicHandler) *SchemaBuilder {
+	b.panicHandler = handler
+	return b
+}
+
+// withPanicHandler runs resolve converting panics into errors after calling
+// handler
+func withPanicHandler(handler PanicHandler, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (value interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				handler(recovered, p)
+				value, err = nil, fmt.Errorf("panic resolving %s: %v", p.Info.FieldName, recovered)
+			}
+		}()
+		return resolve(p)
+	}
+}
@@ -0,0 +1,50 @@
+package gql
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type PanickingHost struct{}
+
+func (h *PanickingHost) Broken(ctx context.Context) (string, error) {
+	panic("boom")
+}
+
+func (h *PanickingHost) Fine() (string, error) {
+	return "ok", nil
+}
+
+func TestWithPanicHandler(t *testing.T) {
+	var recovered interface{}
+	var fieldName string
+	schema, err := NewSchemaBuilder().
+		WithPanicHandler(func(value interface{}, p graphql.ResolveParams) {
+			recovered, fieldName = value, p.Info.FieldName
+		}).
+		WithFieldTimeout("broken", time.Second).
+		WithQuery(&PanickingHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ broken fine }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "boom") {
+		t.Fatalf("expected the panic as an error, got %v", result.Errors)
+	}
+	if recovered != "boom" || fieldName != "broken" {
+		t.Fatalf("expected the handler to receive boom for broken, got %v for %s", recovered, fieldName)
+	}
+	if fine := result.Data.(map[string]interface{})["fine"]; fine != "ok" {
+		t.Fatalf("expected ok, got %v", fine)
+	}
+}
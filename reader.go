@@ -0,0 +1,32 @@
+package gql
+
+import (
+	"io"
+	"reflect"
+)
+
+var readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+
+// isReaderType reports whether t implements io.Reader, exposed as a String
+// read fully when resolved
+func isReaderType(t reflect.Type) bool {
+	return t.Implements(readerType)
+}
+
+// readValue reads value into a string if it is an io.Reader, closing it when
+// it is also an io.Closer. Other values are returned as is.
+func readValue(value interface{}) (interface{}, error) {
+	reader, ok := value.(io.Reader)
+	if !ok {
+		return value, nil
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,75 @@
+package gql
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type closingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+type Document struct {
+	Title string    `gql:"title"`
+	Body  io.Reader `gql:"body"`
+}
+
+type ReaderHost struct {
+	log *closingReader
+}
+
+func (h *ReaderHost) Readme() (*strings.Reader, error) {
+	return strings.NewReader("# gql"), nil
+}
+
+func (h *ReaderHost) Log() (io.Reader, error) {
+	return h.log, nil
+}
+
+func (h *ReaderHost) Document() (*Document, error) {
+	return &Document{Title: "notes", Body: strings.NewReader("long text")}, nil
+}
+
+func TestReaderOutputs(t *testing.T) {
+	host := &ReaderHost{log: &closingReader{Reader: strings.NewReader("started")}}
+	schema, err := NewSchemaBuilder().WithQuery(host).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if fieldType := schema.QueryType().Fields()["readme"].Type.String(); fieldType != "String" {
+		t.Fatalf("expected String, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ readme log document { title body } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"readme":   "# gql",
+		"log":      "started",
+		"document": map[string]interface{}{"title": "notes", "body": "long text"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+	if !host.log.closed {
+		t.Fatalf("expected the reader to be closed")
+	}
+}
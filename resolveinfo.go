@@ -1,8 +1,12 @@
 package gql
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/graphql-go/graphql"
 )
@@ -10,7 +14,7 @@ import (
 /*
 ResolveInfo is a struct that contains information about the function that is being resolved.
 
-It contains the function itself, the source, context, info, input, output and error.
+It contains the function itself, the source, context, info, params, input, output and error.
 
 The source, context, info, input and output are all ArgInfo structs.
 
@@ -25,13 +29,85 @@ type ResolveInfo struct {
 	Source  *ArgInfo
 	Context *ArgInfo
 	Info    *ArgInfo
+	Params  *ArgInfo
 	Input   *ArgInfo
 	Output  *ArgInfo
 	Error   *ArgInfo
 
+	// Found is a boolean second return value used instead of an error,
+	// resolving the field to null when false
+	Found *ArgInfo
+
 	// BoundReceiver holds the instance to be used as the receiver
 	// If set, Source.ValueFrom(p.Source) is skipped for the receiver
 	BoundReceiver *reflect.Value
+
+	// ScalarInputName is the argument name of a non-struct input
+	// It is only set when scalar inputs are enabled in the ResolveConfig
+	ScalarInputName string
+
+	// Provided holds the parameters populated by context providers
+	Provided []*ProvidedArg
+
+	// ErrorOnly marks a resolver returning only an error, resolving to true
+	// on success. It is only set when enabled in the ResolveConfig
+	ErrorOnly bool
+
+	exposeAllFields bool
+
+	// inputRules are the validation options of the input's gql tags
+	inputRules *inputRules
+
+	// funcFrom reads the func to call from the field params, such as the
+	// func-typed field of the source, Func being a zero func of its type.
+	// An invalid func resolves to null.
+	funcFrom func(p graphql.ResolveParams) (reflect.Value, error)
+}
+
+// ContextProvider extracts a resolver parameter value from the context
+type ContextProvider func(ctx context.Context) (interface{}, error)
+
+// ProvidedArg is a resolver parameter populated by a ContextProvider
+type ProvidedArg struct {
+	*ArgInfo
+	Provider ContextProvider
+}
+
+// ValueFromContext calls the provider and converts its result to the parameter type
+func (a *ProvidedArg) ValueFromContext(ctx context.Context) (reflect.Value, error) {
+	value, err := a.Provider(ctx)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if value == nil {
+		return reflect.Zero(a.Type), nil
+	}
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(a.Type) {
+		return reflect.Value{}, fmt.Errorf("Context provider for %s returned %s", a.Type, v.Type())
+	}
+	return v, nil
+}
+
+// DefaultScalarInputName is the argument name given to scalar inputs
+const DefaultScalarInputName = "input"
+
+// ResolveConfig holds optional settings for resolver signature detection
+type ResolveConfig struct {
+	// ScalarInputs allows a single non-struct input parameter, exposed as
+	// one argument named ScalarInputName (DefaultScalarInputName if empty)
+	ScalarInputs    bool
+	ScalarInputName string
+
+	// ContextProviders maps parameter types to the providers populating them
+	ContextProviders map[reflect.Type]ContextProvider
+
+	// ExposeAllFields treats untagged exported struct fields as exposed
+	ExposeAllFields bool
+
+	// ErrorOnly allows resolvers returning only an error, exposed as
+	// Boolean fields resolving to true on success
+	ErrorOnly bool
 }
 
 func hasStructValidGqlTag(t reflect.Type) bool {
@@ -44,13 +120,127 @@ func hasStructValidGqlTag(t reflect.Type) bool {
 	return false
 }
 
+// hasExposedFields reports whether the struct exposes any field, counting
+// untagged exported fields when exposeAll is set
+func hasExposedFields(t reflect.Type, exposeAll bool) bool {
+	if exposeAll && len(untaggedExportedFields(t)) > 0 {
+		return true
+	}
+	return hasStructValidGqlTag(t)
+}
+
+// skippedMethods lists common non-field methods, by lower camel case name,
+// which are never exposed as getters
+var skippedMethods = map[string]bool{
+	"tableName": true, "tableNames": true,
+	"beforeCreate": true, "afterCreate": true,
+	"beforeUpdate": true, "afterUpdate": true,
+	"beforeDelete": true, "afterDelete": true,
+	"beforeSave": true, "afterSave": true,
+	"afterFind":       true,
+	"string":          true,
+	"graphQLTypeName": true,
+	"getGroups":       true, // Already exposed via Groups field
+}
+
+// hasExposedMethods reports whether the struct, or a pointer to it, has an
+// exported method providing a field of computed objects: a resolver
+// returning a value along with an error or found bool, or a getter returning
+// a single value. Other methods, such as String or setters, don't count.
+func hasExposedMethods(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	for i := 0; i < ptr.NumMethod(); i++ {
+		methodType := ptr.Method(i).Type
+		name := ptr.Method(i).Name
+		switch {
+		case methodType.NumOut() == 2 && methodType.Out(0) != ErrorType &&
+			(methodType.Out(1) == ErrorType || methodType.Out(1).Kind() == reflect.Bool):
+			return true
+		case methodType.NumIn() == 1 && methodType.NumOut() == 1 &&
+			methodType.Out(0) != ErrorType && methodType.Out(0).Kind() != reflect.Interface &&
+			!skippedMethods[strings.ToLower(name[0:1])+name[1:]]:
+			return true
+		}
+	}
+	return false
+}
+
+// untaggedExportedFields lists the names of exported fields without a gql tag
+func untaggedExportedFields(t reflect.Type) []string {
+	names := []string{}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		if _, ok := field.Tag.Lookup(GqlTagKey); !ok {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// FuncName returns the fully qualified name of the resolver function
+func (r *ResolveInfo) FuncName() string {
+	if fn := runtime.FuncForPC(r.Func.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return r.Func.String()
+}
+
+// String summarizes the role detected for each parameter and return value of
+// the resolver along with its index and type, e.g.
+//
+//	main.(*Query).User(source[0] *main.Query, context[1] context.Context, input[2] main.UserInput) (output[0] *main.User, error[1] error)
+func (r *ResolveInfo) String() string {
+	type role struct {
+		name string
+		arg  *ArgInfo
+	}
+	describe := func(roles []role) string {
+		sort.Slice(roles, func(i, j int) bool { return roles[i].arg.Index < roles[j].arg.Index })
+		parts := []string{}
+		for _, role := range roles {
+			parts = append(parts, fmt.Sprintf("%s[%d] %s", role.name, role.arg.Index, role.arg.Type))
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	in := []role{}
+	for _, candidate := range []role{{"source", r.Source}, {"context", r.Context}, {"info", r.Info}, {"params", r.Params}, {"input", r.Input}} {
+		if candidate.arg != nil {
+			in = append(in, candidate)
+		}
+	}
+	for _, provided := range r.Provided {
+		in = append(in, role{"provided", provided.ArgInfo})
+	}
+
+	out := []role{}
+	for _, candidate := range []role{{"output", r.Output}, {"error", r.Error}, {"found", r.Found}} {
+		if candidate.arg != nil {
+			out = append(out, candidate)
+		}
+	}
+
+	return fmt.Sprintf("%s(%s) (%s)", r.FuncName(), describe(in), describe(out))
+}
+
+// OutputType returns the Go type of the resolved value, bool for error only
+// resolvers
+func (r *ResolveInfo) OutputType() reflect.Type {
+	if r.ErrorOnly {
+		return reflect.TypeOf(true)
+	}
+	return r.Output.Type
+}
+
 func (r *ResolveInfo) Validate() error {
-	if r.Input != nil {
+	if r.Input != nil && r.ScalarInputName == "" {
 		if r.Input.RealType.Kind() != reflect.Struct || r.Input.IsSlice {
 			return fmt.Errorf("Input type should be a struct, got %s", r.Input.Type)
 		}
 
-		if !hasStructValidGqlTag(r.Input.RealType) {
+		if !hasExposedFields(r.Input.RealType, r.exposeAllFields) {
 			// Check if it's an anonymous struct (empty name) or named struct
 			// For anonymous structs used as args, we might be more lenient or strict
 			// But for now keeping validation
@@ -58,53 +248,103 @@ func (r *ResolveInfo) Validate() error {
 		}
 	}
 
-	if r.Error == nil {
-		return fmt.Errorf("Resolve method %s should have an error return value", r.Func.String())
+	if r.Error == nil && r.Found == nil {
+		return fmt.Errorf("Resolve method %s should have an error or found (bool) return value", r.Func.String())
+	}
+
+	if r.ErrorOnly {
+		return nil
 	}
 
 	if r.Output == nil {
 		return fmt.Errorf("Resolve method %s should have an output return value", r.Func.String())
 	}
 
-	if r.Output.RealType.Kind() == reflect.Struct && !hasStructValidGqlTag(r.Output.RealType) {
-		return fmt.Errorf("Output type should have at least one visible field with a gql tag")
+	if isReaderType(r.Output.Type) {
+		return nil
+	}
+
+	if r.Output.RealType == ContextType || r.Output.RealType == InfoType || r.Output.RealType == ParamsType {
+		return fmt.Errorf("Output type %s of resolver %s can't be serialized", r.Output.Type, r.FuncName())
+	}
+
+	if r.Output.RealType.Kind() == reflect.Struct && !hasExposedFields(r.Output.RealType, r.exposeAllFields) && !hasExposedMethods(r.Output.RealType) && !isJSONMarshalerStruct(r.Output.RealType) && !isTextScalarType(r.Output.RealType) {
+		return fmt.Errorf(
+			"Output type %s of resolver %s should have at least one visible field with a gql tag or an exported method, untagged exported fields: [%s]",
+			r.Output.RealType, r.FuncName(), strings.Join(untaggedExportedFields(r.Output.RealType), ", "),
+		)
 	}
 
 	return nil
 }
 
 func NewResolveInfo(fn reflect.Value) (*ResolveInfo, error) {
-	r := &ResolveInfo{
-		Func: fn,
+	return NewResolveInfoWithConfig(fn, nil)
+}
+
+// NewResolveInfoWithConfig is like NewResolveInfo but applies the optional
+// settings in config
+func NewResolveInfoWithConfig(fn reflect.Value, config *ResolveConfig) (*ResolveInfo, error) {
+	return newResolveInfo(fn, config, true)
+}
+
+// NewFuncResolveInfo creates a ResolveInfo for a function without receiver,
+// such as a func-typed struct field
+func NewFuncResolveInfo(fn reflect.Value, config *ResolveConfig) (*ResolveInfo, error) {
+	return newResolveInfo(fn, config, false)
+}
+
+func newResolveInfo(fn reflect.Value, config *ResolveConfig, hasReceiver bool) (*ResolveInfo, error) {
+	if config == nil {
+		config = &ResolveConfig{}
 	}
 
-	if fn.Type().NumIn() == 0 {
-		return nil, fmt.Errorf("Resolve method should have a receiver")
+	r := &ResolveInfo{
+		Func:            fn,
+		exposeAllFields: config.ExposeAllFields,
 	}
 
-	r.Source = NewArgInfo(fn.Type().In(0), 0)
+	first := 0
+	if hasReceiver {
+		if fn.Type().NumIn() == 0 {
+			return nil, fmt.Errorf("Resolve method should have a receiver")
+		}
 
-	if r.Source.RealType.Kind() != reflect.Struct {
-		return nil, fmt.Errorf("Resolve method should be hosted on a struct, got %s", r.Source.Type)
-	}
+		r.Source = NewArgInfo(fn.Type().In(0), 0)
 
-	// Other validations on the function signature
-	if fn.Type().NumIn() > 4 {
-		return nil, fmt.Errorf("Resolve method should have at most 4 arguments")
+		isNamedSlice := r.Source.IsSlice && r.Source.Type.Name() != ""
+		if r.Source.RealType.Kind() != reflect.Struct && !isNamedSlice {
+			return nil, fmt.Errorf("Resolve method should be hosted on a struct or named slice, got %s", r.Source.Type)
+		}
+		first = 1
 	}
 
+	// Other validations on the function signature
 	if fn.Type().NumOut() > 2 {
-		return nil, fmt.Errorf("Resolve method should have at most 2 return values")
+		return nil, fmt.Errorf("Resolve method should have at most 2 return values, wrap multiple outputs in a struct with gql tagged fields")
 	}
 
-	// Iterate over the input types and determine the context, info, input and error types
-	// along with the index
-	for i := 1; i < fn.Type().NumIn(); i++ {
+	// Iterate over the input types and determine the context, info, provided and input types
+	// along with the index, any number of them may be combined as long as there is one input
+	for i := first; i < fn.Type().NumIn(); i++ {
 		argInfo := NewArgInfo(fn.Type().In(i), i)
 		if argInfo.RealType == ContextType {
+			if r.Context != nil {
+				return nil, fmt.Errorf("Expected at most one context argument, got %s", argInfo.Type)
+			}
 			r.Context = argInfo
 		} else if argInfo.RealType == InfoType {
+			if r.Info != nil {
+				return nil, fmt.Errorf("Expected at most one info argument, got %s", argInfo.Type)
+			}
 			r.Info = argInfo
+		} else if argInfo.RealType == ParamsType {
+			if r.Params != nil {
+				return nil, fmt.Errorf("Expected at most one params argument, got %s", argInfo.Type)
+			}
+			r.Params = argInfo
+		} else if provider, ok := config.ContextProviders[argInfo.Type]; ok {
+			r.Provided = append(r.Provided, &ProvidedArg{ArgInfo: argInfo, Provider: provider})
 		} else {
 			if r.Input == nil {
 				r.Input = argInfo
@@ -119,15 +359,34 @@ func NewResolveInfo(fn reflect.Value) (*ResolveInfo, error) {
 		argInfo := NewArgInfo(fn.Type().Out(i), i)
 		if argInfo.RealType == ErrorType {
 			r.Error = argInfo
+		} else if i == 1 && argInfo.Type.Kind() == reflect.Bool && r.Error == nil {
+			r.Found = argInfo
 		} else {
 			if r.Output == nil {
 				r.Output = argInfo
 			} else {
-				return nil, fmt.Errorf("Expected at most one output type, got %s", argInfo.Type)
+				return nil, fmt.Errorf("Expected at most one output type, got %s, wrap multiple outputs in a struct with gql tagged fields", argInfo.Type)
 			}
 		}
 	}
 
+	r.ErrorOnly = config.ErrorOnly && r.Output == nil && r.Error != nil
+
+	if config.ScalarInputs && r.Input != nil && !r.Input.IsSlice && r.Input.RealType.Kind() != reflect.Struct {
+		r.ScalarInputName = config.ScalarInputName
+		if r.ScalarInputName == "" {
+			r.ScalarInputName = DefaultScalarInputName
+		}
+	}
+
+	if r.Input != nil && r.ScalarInputName == "" && !r.Input.IsSlice && r.Input.RealType.Kind() == reflect.Struct {
+		rules, err := compileInputRules(r.Input.RealType)
+		if err != nil {
+			return nil, err
+		}
+		r.inputRules = rules
+	}
+
 	if err := r.Validate(); err != nil {
 		return nil, err
 	}
@@ -139,9 +398,22 @@ func (r *ResolveInfo) Resolve(p graphql.ResolveParams) (interface{}, error) {
 	args := make([]reflect.Value, r.Func.Type().NumIn())
 	var err error
 
+	fn := r.Func
+	if r.funcFrom != nil {
+		fn, err = r.funcFrom(p)
+		if err != nil || !fn.IsValid() {
+			return nil, err
+		}
+	}
+
+	// Resolvers called outside of graphql-go's executor may lack a context
+	if p.Context == nil {
+		p.Context = context.Background()
+	}
+
 	if r.BoundReceiver != nil {
 		args[0] = *r.BoundReceiver
-	} else {
+	} else if r.Source != nil {
 		args[0], err = r.Source.ValueFrom(p.Source)
 		if err != nil {
 			return nil, err
@@ -150,11 +422,27 @@ func (r *ResolveInfo) Resolve(p graphql.ResolveParams) (interface{}, error) {
 
 	// If there is an input, place it in the input index
 
-	if r.Input != nil {
+	if r.Input != nil && r.ScalarInputName != "" {
+		args[r.Input.Index], err = r.scalarInputValue(p.Args[r.ScalarInputName])
+		if err != nil {
+			return nil, err
+		}
+	} else if r.Input != nil && r.Input.IsPtr && len(p.Args) == 0 && (r.inputRules == nil || !r.inputRules.oneOf) {
+		// Pointer inputs are nil when no arguments are provided, except oneOf
+		// inputs which must have exactly one field set
+		args[r.Input.Index] = reflect.Zero(r.Input.Type)
+	} else if r.Input != nil {
 		args[r.Input.Index], err = r.Input.ValueFrom(p.Args)
 		if err != nil {
 			return nil, err
 		}
+
+		// Enforce the validation options of the input's gql tags
+		if r.inputRules != nil {
+			if err := r.inputRules.validate(args[r.Input.Index], p.Args); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// If there is a context, place it in the context index
@@ -170,13 +458,36 @@ func (r *ResolveInfo) Resolve(p graphql.ResolveParams) (interface{}, error) {
 		}
 	}
 
+	// If there are params, place them or a pointer to them in the params index
+	if r.Params != nil {
+		if r.Params.IsPtr {
+			args[r.Params.Index] = reflect.ValueOf(&p)
+		} else {
+			args[r.Params.Index] = reflect.ValueOf(p)
+		}
+	}
+
+	// Populate the context provided parameters
+	for _, provided := range r.Provided {
+		args[provided.Index], err = provided.ValueFromContext(p.Context)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Call the function with the arguments in the correct order
-	values := r.Func.Call(args)
+	values := fn.Call(args)
 
 	// If there is an output, place it in the output index
 	var output interface{}
 	if r.Output != nil {
-		output = values[r.Output.Index].Interface()
+		output = valueInterface(values[r.Output.Index])
+	} else if r.ErrorOnly {
+		output = true
+	}
+
+	if r.Found != nil && !values[r.Found.Index].Bool() {
+		return nil, nil
 	}
 
 	if r.Error != nil {
@@ -185,5 +496,46 @@ func (r *ResolveInfo) Resolve(p graphql.ResolveParams) (interface{}, error) {
 			return nil, err
 		}
 	}
-	return output, nil
+	return readValue(output)
+}
+
+// valueInterface returns the value as an interface, using an untyped nil for
+// nil pointers and interfaces so that graphql-go renders them as null.
+// Pointers to non-struct values are dereferenced, as scalars such as ID don't
+// serialize pointers to named types like *CustomID.
+func valueInterface(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	if v.Kind() == reflect.Ptr && !isReaderType(v.Type()) {
+		switch v.Elem().Kind() {
+		case reflect.Struct, reflect.Interface, reflect.Ptr:
+		default:
+			return v.Elem().Interface()
+		}
+	}
+	return v.Interface()
+}
+
+// scalarInputValue converts a scalar argument value into the input parameter type
+func (r *ResolveInfo) scalarInputValue(value interface{}) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(r.Input.Type), nil
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.Type().ConvertibleTo(r.Input.RealType) {
+		return reflect.Value{}, fmt.Errorf("Cannot use %s as %s input", v.Type(), r.Input.RealType)
+	}
+	v = v.Convert(r.Input.RealType)
+
+	if r.Input.IsPtr {
+		ptr := reflect.New(r.Input.RealType)
+		ptr.Elem().Set(v)
+		return ptr, nil
+	}
+	return v, nil
 }
@@ -2,7 +2,9 @@ package gql
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/graphql-go/graphql"
@@ -69,6 +71,31 @@ func (f FixtureType) MoreThanOneInputType(
 	return "foo", nil
 }
 
+func (f FixtureType) TwoContexts(a context.Context, b context.Context) (string, error) {
+	return "foo", nil
+}
+
+func (f FixtureType) AllParameters(
+	a context.Context,
+	b graphql.ResolveInfo,
+	c *AuthUser,
+	d ValidFixtureInput,
+) (string, error) {
+	return "foo", nil
+}
+
+func (f FixtureType) ContextOutput(a context.Context) (context.Context, error) {
+	return a, nil
+}
+
+func (f FixtureType) InfoOutput(a graphql.ResolveInfo) (*graphql.ResolveInfo, error) {
+	return &a, nil
+}
+
+func (f FixtureType) TwoOutputs() (int, string) {
+	return 1, "foo"
+}
+
 func (f FixtureType) MoreThanTwoReturns(a ValidFixtureInput, b context.Context, c graphql.ResolveInfo) (int, string, error) {
 	return 1, "foo", nil
 }
@@ -81,6 +108,10 @@ func (f FixtureType) InvalidInput(a InvalidFixtureInput) (string, error) {
 	return "foo", nil
 }
 
+func (f FixtureType) InvalidOutput() (InvalidFixtureOutput, error) {
+	return InvalidFixtureOutput{}, nil
+}
+
 func (f FixtureType) TwoInputs(a ValidFixtureInput, b context.Context) (string, error) {
 	return "foo", nil
 }
@@ -148,6 +179,18 @@ func TestNewResolveInfo(t *testing.T) {
 			fn:      fnMap["MoreThanOneInputType"],
 			isError: true,
 		},
+		{
+			fn:      fnMap["TwoContexts"],
+			isError: true,
+		},
+		{
+			fn:      fnMap["ContextOutput"],
+			isError: true,
+		},
+		{
+			fn:      fnMap["InfoOutput"],
+			isError: true,
+		},
 		{
 			fn:      fnMap["MoreThanTwoReturns"],
 			isError: true,
@@ -179,3 +222,406 @@ func TestNewResolveInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestNewResolveInfoWithScalarInputs(t *testing.T) {
+	method, _ := reflect.TypeOf(FixtureType{}).MethodByName("NonStructInput")
+
+	r, err := NewResolveInfoWithConfig(method.Func, &ResolveConfig{ScalarInputs: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if r.ScalarInputName != DefaultScalarInputName {
+		t.Fatalf("expected scalar input name %s, got %s", DefaultScalarInputName, r.ScalarInputName)
+	}
+
+	r, err = NewResolveInfoWithConfig(method.Func, &ResolveConfig{ScalarInputs: true, ScalarInputName: "id"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if r.ScalarInputName != "id" {
+		t.Fatalf("expected scalar input name id, got %s", r.ScalarInputName)
+	}
+}
+
+func TestNewResolveInfoUntaggedOutputError(t *testing.T) {
+	method, _ := reflect.TypeOf(FixtureType{}).MethodByName("InvalidOutput")
+
+	_, err := NewResolveInfo(method.Func)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	for _, expected := range []string{"gql.InvalidFixtureOutput", "FixtureType.InvalidOutput", "[A, B]"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %q", expected, err.Error())
+		}
+	}
+}
+
+func TestNewResolveInfoWithAllParameters(t *testing.T) {
+	method, _ := reflect.TypeOf(FixtureType{}).MethodByName("AllParameters")
+
+	r, err := NewResolveInfoWithConfig(method.Func, &ResolveConfig{
+		ContextProviders: map[reflect.Type]ContextProvider{
+			reflect.TypeOf(&AuthUser{}): func(ctx context.Context) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if r.Context == nil || r.Info == nil || r.Input == nil || len(r.Provided) != 1 {
+		t.Fatalf("expected context, info, input and one provided argument, got %+v", r)
+	}
+}
+
+func TestResolveInfoString(t *testing.T) {
+	method, _ := reflect.TypeOf(FixtureType{}).MethodByName("AllParameters")
+
+	r, err := NewResolveInfoWithConfig(method.Func, &ResolveConfig{
+		ContextProviders: map[reflect.Type]ContextProvider{
+			reflect.TypeOf(&AuthUser{}): func(ctx context.Context) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "github.com/kadirpekel/gql.FixtureType.AllParameters(source[0] gql.FixtureType, context[1] context.Context, " +
+		"info[2] graphql.ResolveInfo, provided[3] *gql.AuthUser, input[4] gql.ValidFixtureInput) (output[0] string, error[1] error)"
+	if actual := r.String(); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestNewResolveInfoContextOutputError(t *testing.T) {
+	method, _ := reflect.TypeOf(FixtureType{}).MethodByName("ContextOutput")
+
+	_, err := NewResolveInfo(method.Func)
+	if err == nil || !strings.Contains(err.Error(), "context.Context") {
+		t.Fatalf("expected error naming context.Context, got %v", err)
+	}
+}
+
+func TestNewResolveInfoTupleOutputError(t *testing.T) {
+	for _, name := range []string{"TwoOutputs", "MoreThanTwoReturns"} {
+		method, _ := reflect.TypeOf(FixtureType{}).MethodByName(name)
+
+		_, err := NewResolveInfo(method.Func)
+		if err == nil || !strings.Contains(err.Error(), "wrap multiple outputs in a struct") {
+			t.Errorf("expected %s error to suggest wrapping outputs in a struct, got %v", name, err)
+		}
+	}
+}
+
+type ComputedCircle struct {
+	radius float64
+}
+
+func (c *ComputedCircle) Area() float64 {
+	return 3 * c.radius * c.radius
+}
+
+func (c *ComputedCircle) Scaled(input ScaleInput) (*ComputedCircle, error) {
+	return &ComputedCircle{radius: c.radius * input.Factor}, nil
+}
+
+type ScaleInput struct {
+	Factor float64 `gql:"factor,nonNull"`
+}
+
+type ComputedHost struct{}
+
+func (h *ComputedHost) Circle() (*ComputedCircle, error) {
+	return &ComputedCircle{radius: 2}, nil
+}
+
+func TestMethodsOnlyOutput(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ComputedHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ circle { area scaled(factor: 2) { area } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"circle": map[string]interface{}{
+			"area":   12.0,
+			"scaled": map[string]interface{}{"area": 48.0},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type OpaqueToken struct {
+	value string
+}
+
+func (t OpaqueToken) String() string {
+	return t.value
+}
+
+func (t *OpaqueToken) SetValue(value string) {
+	t.value = value
+}
+
+type OpaqueHost struct{}
+
+func (h *OpaqueHost) Token() (*OpaqueToken, error) {
+	return &OpaqueToken{value: "secret"}, nil
+}
+
+func TestMethodsWithoutFieldsOutputError(t *testing.T) {
+	method, _ := reflect.TypeOf(&OpaqueHost{}).MethodByName("Token")
+
+	_, err := NewResolveInfo(method.Func)
+	if err == nil || !strings.Contains(err.Error(), "should have at least one visible field") {
+		t.Fatalf("expected an output type error, got %v", err)
+	}
+}
+
+type UserFilterInput struct {
+	Name string `gql:"name"`
+}
+
+type FilterHost struct{}
+
+func (h *FilterHost) Users(ctx context.Context, filter *UserFilterInput) (string, error) {
+	if filter == nil {
+		return "all", nil
+	}
+	return "name=" + filter.Name, nil
+}
+
+func TestPointerInputWithoutArguments(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&FilterHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := map[string]string{
+		`{ users }`:              "all",
+		`{ users(name: "ada") }`: "name=ada",
+		`{ users(name: "") }`:    "name=",
+	}
+	for query, expected := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: query,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors for %s, got %v", query, result.Errors)
+		}
+		if users := result.Data.(map[string]interface{})["users"]; users != expected {
+			t.Fatalf("expected %s for %s, got %v", expected, query, users)
+		}
+	}
+}
+
+type ParamsHost struct{}
+
+func (h *ParamsHost) Tenant(p *graphql.ResolveParams) (string, error) {
+	root := p.Info.RootValue.(map[string]interface{})
+	return root["tenant"].(string) + ":" + p.Info.FieldName, nil
+}
+
+func (h *ParamsHost) Args(input UserFilterInput, p graphql.ResolveParams) (string, error) {
+	return input.Name + ":" + p.Args["name"].(string), nil
+}
+
+func TestResolveParamsArguments(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ParamsHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ tenant args(name: "ada") }`,
+		RootObject:    map[string]interface{}{"tenant": "acme"},
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"tenant": "acme:tenant", "args": "ada:ada"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type ArchiveInput struct {
+	ID string `gql:"id,nonNull"`
+}
+
+type ArchiveQuery struct{}
+
+func (q *ArchiveQuery) Archived(input ArchiveInput) (bool, error) {
+	return input.ID == "a1", nil
+}
+
+type ArchiveMutation struct{}
+
+func (m *ArchiveMutation) Archive(input ArchiveInput) (bool, error) {
+	return input.ID != "", nil
+}
+
+func (m *ArchiveMutation) Delete(input ArchiveInput) error {
+	if input.ID == "missing" {
+		return errors.New("not found")
+	}
+	return nil
+}
+
+func TestErrorOnlyResolvers(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithErrorOnlyResolvers(true).
+		WithQuery(&ArchiveQuery{}).
+		WithMutation(&ArchiveMutation{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.MutationType().Fields()
+	for _, name := range []string{"archive", "delete"} {
+		if fieldType := fields[name].Type.String(); fieldType != "Boolean" {
+			t.Fatalf("expected Boolean for %s, got %s", name, fieldType)
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `mutation { archive(id: "a1") delete(id: "a1") }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+	expected := map[string]interface{}{"archive": true, "delete": true}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `mutation { delete(id: "missing") }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "not found" {
+		t.Fatalf("expected not found error, got %v", result.Errors)
+	}
+	expected = map[string]interface{}{"delete": nil}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestNewResolveInfoErrorOnly(t *testing.T) {
+	method, _ := reflect.TypeOf(&ArchiveMutation{}).MethodByName("Delete")
+	if _, err := NewResolveInfo(method.Func); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	r, err := NewResolveInfoWithConfig(method.Func, &ResolveConfig{ErrorOnly: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !r.ErrorOnly || r.OutputType() != reflect.TypeOf(true) {
+		t.Fatalf("expected error only resolver with bool output, got %s", r.OutputType())
+	}
+}
+
+type permutationKey struct{}
+
+// permutations returns all orderings of types
+func permutations(types []reflect.Type) [][]reflect.Type {
+	if len(types) <= 1 {
+		return [][]reflect.Type{types}
+	}
+	result := [][]reflect.Type{}
+	for i := range types {
+		rest := append(append([]reflect.Type{}, types[:i]...), types[i+1:]...)
+		for _, permutation := range permutations(rest) {
+			result = append(result, append([]reflect.Type{types[i]}, permutation...))
+		}
+	}
+	return result
+}
+
+func TestResolveParameterPermutations(t *testing.T) {
+	stringType := reflect.TypeOf("")
+	inputs := []reflect.Type{ContextType, InfoType, reflect.TypeOf(TaggedNonNull{})}
+	outputOrders := [][]reflect.Type{{stringType, ErrorType}, {ErrorType, stringType}}
+
+	for _, in := range permutations(inputs) {
+		for _, out := range outputOrders {
+			fnType := reflect.FuncOf(in, out, false)
+			fn := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+				var ctxValue, fieldName, field string
+				for _, arg := range args {
+					switch v := arg.Interface().(type) {
+					case context.Context:
+						ctxValue, _ = v.Value(permutationKey{}).(string)
+					case graphql.ResolveInfo:
+						fieldName = v.FieldName
+					case TaggedNonNull:
+						field = v.Field
+					}
+				}
+				results := make([]reflect.Value, len(out))
+				for i, outType := range out {
+					if outType == ErrorType && field == "fail" {
+						results[i] = reflect.ValueOf(errors.New("failed")).Convert(ErrorType)
+					} else if outType == ErrorType {
+						results[i] = reflect.Zero(ErrorType)
+					} else {
+						results[i] = reflect.ValueOf(ctxValue + " " + fieldName + " " + field)
+					}
+				}
+				return results
+			})
+
+			r, err := NewFuncResolveInfo(fn, nil)
+			if err != nil {
+				t.Fatalf("expected no error for %s, got %v", fnType, err)
+			}
+			if r.Context == nil || r.Info == nil || r.Input == nil || r.Output == nil || r.Error == nil {
+				t.Fatalf("expected all roles detected for %s, got %s", fnType, r)
+			}
+			if r.Output.Type != stringType || r.Error.Index == r.Output.Index {
+				t.Fatalf("expected distinct output and error for %s, got %s", fnType, r)
+			}
+
+			value, err := r.Resolve(graphql.ResolveParams{
+				Context: context.WithValue(context.Background(), permutationKey{}, "ctxValue"),
+				Info:    graphql.ResolveInfo{FieldName: "field"},
+				Args:    map[string]interface{}{"field": "foobar"},
+			})
+			if err != nil {
+				t.Fatalf("expected no error resolving %s, got %v", fnType, err)
+			}
+			if value != "ctxValue field foobar" {
+				t.Fatalf("expected %q resolving %s, got %v", "ctxValue field foobar", fnType, value)
+			}
+
+			if _, err := r.Resolve(graphql.ResolveParams{Args: map[string]interface{}{"field": "fail"}}); err == nil || err.Error() != "failed" {
+				t.Fatalf("expected failed error resolving %s, got %v", fnType, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// boundResolver is a method of an existing value exposed as a query field
+type boundResolver struct {
+	field      string
+	instance   interface{}
+	methodName string
+}
+
+// WithResolverInstance exposes the method methodName of instance as the query
+// field named field, with instance as its receiver. This allows binding
+// resolvers of values, such as third-party services, that aren't part of the
+// query struct. Fields already defined are reported as conflicts.
+func (b *SchemaBuilder) WithResolverInstance(field string, instance interface{}, methodName string) *SchemaBuilder {
+	b.boundResolvers = append(b.boundResolvers, boundResolver{
+		field:      field,
+		instance:   instance,
+		methodName: methodName,
+	})
+	return b
+}
+
+// addBoundResolvers adds the fields of the bound resolvers to the query
+// object, creating it if the builder has no query struct
+func (b *SchemaBuilder) addBoundResolvers(queryObject *graphql.Object) (*graphql.Object, error) {
+	if len(b.boundResolvers) == 0 {
+		return queryObject, nil
+	}
+
+	// Bound fields are reported as conflicts rather than replacing fields of
+	// the query struct or each other. Fields of the created object can't be
+	// listed until it has one.
+	defined := map[string]bool{}
+	if queryObject != nil {
+		for name := range queryObject.Fields() {
+			defined[name] = true
+		}
+	}
+
+	for _, bound := range b.boundResolvers {
+		method, ok := reflect.TypeOf(bound.instance).MethodByName(bound.methodName)
+		if !ok {
+			return nil, fmt.Errorf("%T has no method %s", bound.instance, bound.methodName)
+		}
+
+		resolveInfo, err := b.newResolveInfo(method.Func)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind %T.%s: %w", bound.instance, bound.methodName, err)
+		}
+		receiver := reflect.ValueOf(bound.instance)
+		resolveInfo.BoundReceiver = &receiver
+
+		graphqlField, err := b.resolverAsGraphqlField(bound.field, resolveInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		if queryObject == nil {
+			name := string(Query)
+			if rootName, ok := b.rootNames[Query]; ok {
+				name = rootName
+			}
+			queryObject = graphql.NewObject(graphql.ObjectConfig{
+				Name:   name,
+				Fields: graphql.Fields{},
+			})
+		}
+		if defined[bound.field] {
+			return nil, fmt.Errorf("conflicting root field %s on %s bound to %T.%s", bound.field, queryObject.Name(), bound.instance, bound.methodName)
+		}
+		defined[bound.field] = true
+		queryObject.AddFieldConfig(bound.field, graphqlField)
+	}
+	return queryObject, nil
+}
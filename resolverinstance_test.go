@@ -0,0 +1,92 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+// GreetingService stands for a third-party value whose methods are bound
+type GreetingService struct {
+	greeting string
+}
+
+func (s *GreetingService) Greet(input Tagged) (string, error) {
+	return s.greeting + " " + input.Field, nil
+}
+
+func TestWithResolverInstance(t *testing.T) {
+	cases := []struct {
+		builder  *SchemaBuilder
+		query    string
+		expected map[string]interface{}
+	}{
+		{
+			builder: NewSchemaBuilder().WithQuery(&HandlerQuery{}),
+			query:   `{ hello greet(field: "ada") }`,
+			expected: map[string]interface{}{
+				"hello": "world",
+				"greet": "hi ada",
+			},
+		},
+		{
+			builder:  NewSchemaBuilder(),
+			query:    `{ greet(field: "bob") }`,
+			expected: map[string]interface{}{"greet": "hi bob"},
+		},
+	}
+
+	for _, c := range cases {
+		schema, err := c.builder.
+			WithResolverInstance("greet", &GreetingService{greeting: "hi"}, "Greet").
+			BuildSchema()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: c.query,
+			Context:       context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		if !reflect.DeepEqual(result.Data, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, result.Data)
+		}
+	}
+}
+
+func TestWithResolverInstanceMissingMethod(t *testing.T) {
+	_, err := NewSchemaBuilder().
+		WithResolverInstance("greet", &GreetingService{}, "Missing").
+		BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "has no method Missing") {
+		t.Fatalf("expected missing method error, got %v", err)
+	}
+}
+
+func TestWithResolverInstanceConflict(t *testing.T) {
+	// Bound fields don't replace fields of the query struct
+	_, err := NewSchemaBuilder().
+		WithQuery(&HandlerQuery{}).
+		WithResolverInstance("hello", &GreetingService{}, "Greet").
+		BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "conflicting root field hello") {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+
+	// Nor each other
+	_, err = NewSchemaBuilder().
+		WithResolverInstance("greet", &GreetingService{}, "Greet").
+		WithResolverInstance("greet", &GreetingService{}, "Greet").
+		BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "conflicting root field greet") {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package gql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// ResolveType returns the object type the builder generated for the runtime
+// Go type of p.Value, or nil if it has none. It serves as the ResolveType of
+// hand-built unions and interfaces whose members are generated objects, so
+// that __typename resolves to the concrete type of each value.
+func (b *SchemaBuilder) ResolveType(p graphql.ResolveTypeParams) *graphql.Object {
+	if p.Value == nil {
+		return nil
+	}
+	t := reflect.TypeOf(p.Value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	object, _ := b.typeRegistry[t].(*graphql.Object)
+	return object
+}
@@ -0,0 +1,143 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type HumanActor struct {
+	Name string `gql:"name"`
+}
+
+type BotActor struct {
+	Model string `gql:"model"`
+}
+
+type ActorQuery struct{}
+
+func (q *ActorQuery) Human() (*HumanActor, error) {
+	return &HumanActor{}, nil
+}
+
+func (q *ActorQuery) Bot() (BotActor, error) {
+	return BotActor{}, nil
+}
+
+func TestResolveTypeUnion(t *testing.T) {
+	b := NewSchemaBuilder().WithRootName(Query, "Query")
+	schema, err := b.WithQuery(&ActorQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	types := b.RegisteredTypes()
+	actor := graphql.NewUnion(graphql.UnionConfig{
+		Name: "Actor",
+		Types: []*graphql.Object{
+			types[reflect.TypeOf(HumanActor{})].(*graphql.Object),
+			types[reflect.TypeOf(BotActor{})].(*graphql.Object),
+		},
+		ResolveType: b.ResolveType,
+	})
+	err = b.ExtendType(schema, "Query", graphql.Fields{
+		"actors": &graphql.Field{
+			Type: graphql.NewList(actor),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return []interface{}{&HumanActor{Name: "ada"}, BotActor{Model: "r2"}}, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ actors { __typename ... on HumanActor { name } ... on BotActor { model } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"actors": []interface{}{
+			map[string]interface{}{"__typename": "HumanActor", "name": "ada"},
+			map[string]interface{}{"__typename": "BotActor", "model": "r2"},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type Pet interface {
+	Sound() string
+}
+
+type Dog struct {
+	Name string `gql:"name"`
+}
+
+func (d *Dog) Sound() string {
+	return "woof"
+}
+
+type PetInput struct {
+	Name string `gql:"name"`
+}
+
+type PetQuery struct{}
+
+func (q *PetQuery) Dog() (*Dog, error) {
+	return &Dog{Name: "rex"}, nil
+}
+
+func (q *PetQuery) Pet(input PetInput) (Pet, error) {
+	switch input.Name {
+	case "":
+		return nil, nil
+	case "stray":
+		var stray *Dog
+		return stray, nil
+	}
+	return &Dog{Name: input.Name}, nil
+}
+
+func TestNullableInterfaceResult(t *testing.T) {
+	b := NewSchemaBuilder()
+	pet := graphql.NewUnion(graphql.UnionConfig{
+		Name: "PetUnion",
+		Types: graphql.UnionTypesThunk(func() []*graphql.Object {
+			return []*graphql.Object{b.RegisteredTypes()[reflect.TypeOf(Dog{})].(*graphql.Object)}
+		}),
+		ResolveType: b.ResolveType,
+	})
+	b.RegisterCustomType(reflect.TypeOf((*Pet)(nil)).Elem(), pet)
+
+	schema, err := b.WithQuery(&PetQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ none: pet { __typename } stray: pet(name: "stray") { __typename } pet(name: "odie") { __typename ... on Dog { name sound } } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"none":  nil,
+		"stray": nil,
+		"pet":   map[string]interface{}{"__typename": "Dog", "name": "odie", "sound": "woof"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
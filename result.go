@@ -0,0 +1,38 @@
+package gql
+
+import "reflect"
+
+// UserError is a validation failure reported as data rather than through the
+// top-level errors of the response
+type UserError struct {
+	Field   string `gql:"field"`
+	Message string `gql:"message"`
+}
+
+// Result wraps a mutation output following the errors-as-data pattern. It
+// maps to an object named after T, e.g. Result[*User] maps to
+//
+//	type UserResult {
+//		result: User
+//		userErrors: [UserError]!
+//	}
+type Result[T any] struct {
+	Result     T           `gql:"result"`
+	UserErrors []UserError `gql:"userErrors,nonNull"`
+}
+
+// AddUserError appends a user error for field
+func (r *Result[T]) AddUserError(field string, message string) {
+	r.UserErrors = append(r.UserErrors, UserError{Field: field, Message: message})
+}
+
+// HasUserErrors reports whether any user error was added
+func (r *Result[T]) HasUserErrors() bool {
+	return len(r.UserErrors) > 0
+}
+
+// GraphQLTypeName names the object after the wrapped type, e.g. UserResult
+// for Result[*User] and UserListResult for Result[[]*User]
+func (r Result[T]) GraphQLTypeName() string {
+	return typeArgumentName(reflect.TypeOf((*T)(nil)).Elem()) + "Result"
+}
@@ -0,0 +1,110 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Account struct {
+	Email string `gql:"email"`
+}
+
+type CreateAccountInput struct {
+	Email string `gql:"email,nonNull"`
+}
+
+type ResultMutation struct{}
+
+func (m *ResultMutation) CreateAccount(input CreateAccountInput) (*Result[*Account], error) {
+	result := &Result[*Account]{}
+	if input.Email == "" {
+		result.AddUserError("email", "email is required")
+		return result, nil
+	}
+	result.Result = &Account{Email: input.Email}
+	return result, nil
+}
+
+func TestResultUserErrors(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithQuery(&HandlerQuery{}).
+		WithMutation(&ResultMutation{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if fieldType := schema.MutationType().Fields()["createAccount"].Type.String(); fieldType != "AccountResult" {
+		t.Fatalf("expected AccountResult, got %s", fieldType)
+	}
+
+	cases := []struct {
+		email    string
+		expected map[string]interface{}
+	}{
+		{
+			email: "a@b.c",
+			expected: map[string]interface{}{
+				"result":     map[string]interface{}{"email": "a@b.c"},
+				"userErrors": []interface{}{},
+			},
+		},
+		{
+			email: "",
+			expected: map[string]interface{}{
+				"result": nil,
+				"userErrors": []interface{}{
+					map[string]interface{}{"field": "email", "message": "email is required"},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:         *schema,
+			RequestString:  `mutation($email: String!) { createAccount(email: $email) { result { email } userErrors { field message } } }`,
+			VariableValues: map[string]interface{}{"email": c.email},
+			Context:        context.Background(),
+		})
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		expected := map[string]interface{}{"createAccount": c.expected}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("expected %v, got %v", expected, result.Data)
+		}
+	}
+}
+
+type ImportMutation struct{}
+
+func (m *ImportMutation) ImportAccount(input CreateAccountInput) (*Result[*Account], error) {
+	return &Result[*Account]{Result: &Account{Email: input.Email}}, nil
+}
+
+func (m *ImportMutation) ImportAccounts(input CreateAccountInput) (*Result[[]*Account], error) {
+	return &Result[[]*Account]{Result: []*Account{{Email: input.Email}}}, nil
+}
+
+func TestResultListTypeName(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithQuery(&HandlerQuery{}).
+		WithMutation(&ImportMutation{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.MutationType().Fields()
+	expected := map[string]string{"importAccount": "AccountResult", "importAccounts": "AccountListResult"}
+	for name, typeName := range expected {
+		if fieldType := fields[name].Type.String(); fieldType != typeName {
+			t.Fatalf("expected %s of type %s, got %s", name, typeName, fieldType)
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package gql
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// createIPScalar creates an IP scalar for net.IP
+func createIPScalar() *graphql.Scalar {
+	parse := func(value string) interface{} {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil
+		}
+		return ip
+	}
+
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "IP",
+		Description: "IP scalar type (IPv4 or IPv6 textual form)",
+		Serialize: func(value interface{}) interface{} {
+			switch v := value.(type) {
+			case net.IP:
+				if v == nil {
+					return nil
+				}
+				return v.String()
+			case *net.IP:
+				if v == nil || *v == nil {
+					return nil
+				}
+				return v.String()
+			default:
+				return nil
+			}
+		},
+		ParseValue: func(value interface{}) interface{} {
+			if v, ok := value.(string); ok {
+				return parse(v)
+			}
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if strValue, ok := valueAST.(*ast.StringValue); ok {
+				return parse(strValue.Value)
+			}
+			return nil
+		},
+	})
+}
+
+// createURLScalar creates a URL scalar for url.URL
+func createURLScalar() *graphql.Scalar {
+	parse := func(value string) interface{} {
+		u, err := url.Parse(value)
+		if err != nil {
+			return nil
+		}
+		return *u
+	}
+
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "URL",
+		Description: "URL scalar type (RFC 3986 format)",
+		Serialize: func(value interface{}) interface{} {
+			switch v := value.(type) {
+			case url.URL:
+				return v.String()
+			case *url.URL:
+				if v == nil {
+					return nil
+				}
+				return v.String()
+			default:
+				return nil
+			}
+		},
+		ParseValue: func(value interface{}) interface{} {
+			if v, ok := value.(string); ok {
+				return parse(v)
+			}
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if strValue, ok := valueAST.(*ast.StringValue); ok {
+				return parse(strValue.Value)
+			}
+			return nil
+		},
+	})
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// isJSONMarshalerStruct reports whether t is a struct without gql tags whose
+// value or pointer implements json.Marshaler
+func isJSONMarshalerStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || hasStructValidGqlTag(t) {
+		return false
+	}
+	return t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType)
+}
+
+// JSON is a scalar for arbitrary JSON values. Values implementing
+// json.Marshaler are serialized through MarshalJSON.
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "JSON scalar type (arbitrary JSON value)",
+	Serialize: func(value interface{}) interface{} {
+		marshaler, ok := value.(json.Marshaler)
+		if !ok {
+			// Non-pointer values may implement json.Marshaler on their pointer
+			v := reflect.ValueOf(value)
+			if !v.IsValid() {
+				return nil
+			}
+			ptr := reflect.New(v.Type())
+			ptr.Elem().Set(v)
+			if marshaler, ok = ptr.Interface().(json.Marshaler); !ok {
+				return value
+			}
+		}
+
+		data, err := marshaler.MarshalJSON()
+		if err != nil {
+			return nil
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return nil
+		}
+		return decoded
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+// parseJSONLiteral converts a GraphQL literal into its Go JSON representation
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch v := valueAST.(type) {
+	case *ast.StringValue:
+		return v.Value
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.IntValue:
+		n, err := strconv.ParseInt(v.Value, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	case *ast.FloatValue:
+		n, err := strconv.ParseFloat(v.Value, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	case *ast.ListValue:
+		list := make([]interface{}, 0, len(v.Values))
+		for _, item := range v.Values {
+			list = append(list, parseJSONLiteral(item))
+		}
+		return list
+	case *ast.ObjectValue:
+		object := make(map[string]interface{}, len(v.Fields))
+		for _, field := range v.Fields {
+			object[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return object
+	}
+	return nil
+}
+
+// JSONNumber is a scalar for json.Number values, keeping numbers in their
+// exact decimal form. Responses encode them as JSON numbers.
+var JSONNumber = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Number",
+	Description: "Number scalar type (arbitrary precision decimal number)",
+	Serialize:   coerceJSONNumber,
+	ParseValue:  coerceJSONNumber,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch v := valueAST.(type) {
+		case *ast.IntValue:
+			return coerceJSONNumber(v.Value)
+		case *ast.FloatValue:
+			return coerceJSONNumber(v.Value)
+		case *ast.StringValue:
+			return coerceJSONNumber(v.Value)
+		}
+		return nil
+	},
+})
+
+// jsonNumberPattern matches the JSON number grammar, unlike strconv.ParseFloat
+// which also accepts NaN, Inf, hexadecimal and underscored numbers
+var jsonNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// coerceJSONNumber converts numbers and numeric strings into a json.Number,
+// rejecting values that aren't valid JSON numbers
+func coerceJSONNumber(value interface{}) interface{} {
+	var number json.Number
+	switch v := value.(type) {
+	case json.Number:
+		number = v
+	case *json.Number:
+		if v == nil {
+			return nil
+		}
+		number = *v
+	case string:
+		number = json.Number(v)
+	default:
+		var ok bool
+		if number, ok = numberToJSONNumber(value); !ok {
+			return nil
+		}
+	}
+	if !jsonNumberPattern.MatchString(string(number)) {
+		return nil
+	}
+	return number
+}
+
+// numberToJSONNumber formats integer and float values as a json.Number
+func numberToJSONNumber(value interface{}) (json.Number, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(v.Int(), 10)), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(v.Uint(), 10)), true
+	case reflect.Float32, reflect.Float64:
+		return json.Number(strconv.FormatFloat(v.Float(), 'g', -1, 64)), true
+	}
+	return "", false
+}
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// numberToJSONNumberHook decodes numeric values into json.Number fields
+func numberToJSONNumberHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != jsonNumberType {
+		return data, nil
+	}
+	if number, ok := numberToJSONNumber(data); ok {
+		return number, nil
+	}
+	return data, nil
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isTextScalarType reports whether t round-trips through text, its value or
+// pointer implementing encoding.TextMarshaler and its pointer implementing
+// encoding.TextUnmarshaler. Structs with gql tags stay objects.
+func isTextScalarType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface || t.Name() == "" {
+		return false
+	}
+	if t.Kind() == reflect.Struct && hasStructValidGqlTag(t) {
+		return false
+	}
+	ptr := reflect.PointerTo(t)
+	return (t.Implements(textMarshalerType) || ptr.Implements(textMarshalerType)) && ptr.Implements(textUnmarshalerType)
+}
+
+// createTextScalar creates a string scalar named after t serialized through
+// its MarshalText method and parsed through its UnmarshalText method
+func createTextScalar(t reflect.Type) *graphql.Scalar {
+	parse := func(value string) interface{} {
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return nil
+		}
+		return ptr.Elem().Interface()
+	}
+
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        t.Name(),
+		Description: fmt.Sprintf("%s scalar type (text form)", t.Name()),
+		Serialize: func(value interface{}) interface{} {
+			marshaler, ok := value.(encoding.TextMarshaler)
+			if !ok {
+				// Non-pointer values may implement encoding.TextMarshaler on their pointer
+				v := reflect.ValueOf(value)
+				if !v.IsValid() {
+					return nil
+				}
+				ptr := reflect.New(v.Type())
+				ptr.Elem().Set(v)
+				if marshaler, ok = ptr.Interface().(encoding.TextMarshaler); !ok {
+					return nil
+				}
+			}
+
+			text, err := marshaler.MarshalText()
+			if err != nil {
+				return nil
+			}
+			return string(text)
+		},
+		ParseValue: func(value interface{}) interface{} {
+			if v, ok := value.(string); ok {
+				return parse(v)
+			}
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if strValue, ok := valueAST.(*ast.StringValue); ok {
+				return parse(strValue.Value)
+			}
+			return nil
+		},
+	})
+}
+
+// textScalar returns the scalar of a text type, registering it as a custom
+// type on first use so that every field shares the same instance
+func (b *SchemaBuilder) textScalar(t reflect.Type) graphql.Output {
+	if scalar, ok := b.customTypes[t]; ok {
+		return scalar
+	}
+	scalar := createTextScalar(t)
+	b.RegisterCustomType(t, scalar)
+	return scalar
+}
+
+// WithSpecifiedBy records specURL as the specification of the custom scalar,
+// exported as its @specifiedBy directive by the builder's PrintSchema
+func (b *SchemaBuilder) WithSpecifiedBy(scalar *graphql.Scalar, specURL string) *SchemaBuilder {
+	b.specifiedByURLs[scalar] = specURL
+	return b
+}
+
+// literalParser coerces scalar literals through parseValue, as their Go value
+func literalParser(parseValue graphql.ParseValueFn) graphql.ParseLiteralFn {
+	return func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.StringValue:
+			return parseValue(valueAST.Value)
+		case *ast.BooleanValue:
+			return parseValue(valueAST.Value)
+		case *ast.IntValue:
+			if value, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+				return parseValue(int(value))
+			}
+		case *ast.FloatValue:
+			if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+				return parseValue(value)
+			}
+		}
+		return nil
+	}
+}
+
+// unwrapNamedBasic wraps the resolver of an Int, Float or Boolean field whose
+// Go type t is a named basic type, such as type Kelvin float64, or a list of
+// them, converting its values to the underlying kind graphql-go serializes
+func unwrapNamedBasic(field *graphql.Field, t reflect.Type) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	var base reflect.Type
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		base = reflect.TypeOf(int64(0))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		base = reflect.TypeOf(uint64(0))
+	case reflect.Float32, reflect.Float64:
+		base = reflect.TypeOf(float64(0))
+	case reflect.Bool:
+		base = reflect.TypeOf(false)
+	default:
+		return
+	}
+	if t.Name() == t.Kind().String() {
+		return
+	}
+	switch graphql.GetNamed(field.Type) {
+	case graphql.Int, graphql.Float, graphql.Boolean:
+	default:
+		return
+	}
+
+	resolve := field.Resolve
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	field.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := resolve(p)
+		if err != nil || value == nil {
+			return value, err
+		}
+		return convertNamedBasic(reflect.ValueOf(value), t, base), nil
+	}
+}
+
+// convertNamedBasic converts v, or the elements of the lists and pointers it
+// holds, from the named type t to base
+func convertNamedBasic(v reflect.Value, t reflect.Type, base reflect.Type) interface{} {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Type() == t:
+		return v.Convert(base).Interface()
+	case v.Kind() == reflect.Slice && v.IsNil():
+		return nil
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = convertNamedBasic(v.Index(i), t, base)
+		}
+		return items
+	}
+	return v.Interface()
+}
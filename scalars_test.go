@@ -0,0 +1,616 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Endpoint struct {
+	IP  net.IP   `gql:"ip"`
+	URL *url.URL `gql:"url"`
+}
+
+type EndpointInput struct {
+	IP  net.IP   `gql:"ip"`
+	URL *url.URL `gql:"url,nonNull"`
+}
+
+type ScalarHost struct{}
+
+func (h *ScalarHost) Endpoint(input EndpointInput) (*Endpoint, error) {
+	return &Endpoint{IP: input.IP, URL: input.URL}, nil
+}
+
+func TestIPAndURLScalars(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ScalarHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($url: URL!) { endpoint(ip: "192.168.0.1", url: $url) { ip url } }`,
+		VariableValues: map[string]interface{}{"url": "https://example.com/path?q=1"},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"endpoint": map[string]interface{}{
+			"ip":  "192.168.0.1",
+			"url": "https://example.com/path?q=1",
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type Celsius float64
+
+func (c Celsius) String() string {
+	return fmt.Sprintf("%.1f°C", float64(c))
+}
+
+type StringerHost struct{}
+
+func (h *StringerHost) Temperature() (fmt.Stringer, error) {
+	return Celsius(21.5), nil
+}
+
+func (h *StringerHost) Freezing() (Celsius, error) {
+	return Celsius(0), nil
+}
+
+func TestStringerScalar(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithStringerScalar(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()).
+		WithStringerScalar(reflect.TypeOf(Celsius(0))).
+		WithQuery(&StringerHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ temperature freezing }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"temperature": "21.5°C", "freezing": "0.0°C"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type Money struct {
+	cents int64
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"amount":"%d.%02d","currency":"USD"}`, m.cents/100, m.cents%100)), nil
+}
+
+type MoneyHost struct{}
+
+func (h *MoneyHost) Balance() (Money, error) {
+	return Money{cents: 12345}, nil
+}
+
+func (h *MoneyHost) Limit() (*Money, error) {
+	return &Money{cents: 50000}, nil
+}
+
+func TestJSONMarshalerOutput(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&MoneyHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if fieldType := schema.QueryType().Fields()["balance"].Type; fieldType != JSON {
+		t.Fatalf("expected JSON scalar, got %v", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ balance limit }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"balance": map[string]interface{}{"amount": "123.45", "currency": "USD"},
+		"limit":   map[string]interface{}{"amount": "500.00", "currency": "USD"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type Event struct {
+	At       time.Time  `gql:"at"`
+	Deadline *time.Time `gql:"deadline"`
+}
+
+type EventInput struct {
+	At time.Time `gql:"at,nonNull"`
+}
+
+type EventHost struct{}
+
+func (h *EventHost) Reschedule(input EventInput) (*Event, error) {
+	deadline := input.At.Add(time.Hour)
+	return &Event{At: input.At, Deadline: &deadline}, nil
+}
+
+func TestDateTimeScalar(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&EventHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if dateTime := schema.Type("DateTime"); dateTime != graphql.DateTime {
+		t.Fatalf("expected graphql.DateTime, got %v", dateTime)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ reschedule(at: "2024-01-02T15:04:05Z") { at deadline } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"reschedule": map[string]interface{}{
+			"at":       "2024-01-02T15:04:05Z",
+			"deadline": "2024-01-02T16:04:05Z",
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type AnyInput struct {
+	Data interface{} `gql:"data"`
+}
+
+type AnyHost struct{}
+
+func (h *AnyHost) Settings() (interface{}, error) {
+	return map[string]interface{}{"theme": "dark", "sizes": []int{1, 2}}, nil
+}
+
+func (h *AnyHost) Echo(input AnyInput) (interface{}, error) {
+	return input.Data, nil
+}
+
+func TestInterfaceAsJSON(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&AnyHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.QueryType().Fields()
+	if fieldType := fields["settings"].Type.String(); fieldType != "JSON" {
+		t.Fatalf("expected JSON, got %s", fieldType)
+	}
+	if argType := fields["echo"].Args[0].Type.String(); argType != "JSON" {
+		t.Fatalf("expected JSON argument, got %s", argType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($data: JSON) { settings literal: echo(data: {a: [1, "b"]}) variable: echo(data: $data) }`,
+		VariableValues: map[string]interface{}{"data": map[string]interface{}{"nested": true}},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"settings": map[string]interface{}{"theme": "dark", "sizes": []int{1, 2}},
+		"literal":  map[string]interface{}{"a": []interface{}{int64(1), "b"}},
+		"variable": map[string]interface{}{"nested": true},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type SKU struct {
+	Category string
+	Number   int
+}
+
+func (s SKU) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s-%d", s.Category, s.Number)), nil
+}
+
+func (s *SKU) UnmarshalText(text []byte) error {
+	category, number, ok := strings.Cut(string(text), "-")
+	if !ok {
+		return fmt.Errorf("invalid SKU %q", text)
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return err
+	}
+	*s = SKU{Category: category, Number: n}
+	return nil
+}
+
+type Product struct {
+	SKU     SKU  `gql:"sku"`
+	Related *SKU `gql:"related"`
+}
+
+type ProductInput struct {
+	SKU SKU `gql:"sku,nonNull"`
+}
+
+type ProductHost struct{}
+
+func (h *ProductHost) Product(input ProductInput) (*Product, error) {
+	next := SKU{Category: input.SKU.Category, Number: input.SKU.Number + 1}
+	return &Product{SKU: input.SKU, Related: &next}, nil
+}
+
+func (h *ProductHost) NextSKU(input ProductInput) (SKU, error) {
+	return SKU{Category: input.SKU.Category, Number: input.SKU.Number + 1}, nil
+}
+
+func TestTextMarshalerScalar(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ProductHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, ok := schema.Type("SKU").(*graphql.Scalar); !ok {
+		t.Fatalf("expected SKU scalar, got %v", schema.Type("SKU"))
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($sku: SKU!) { product(sku: "book-1") { sku related } nextSKU(sku: $sku) }`,
+		VariableValues: map[string]interface{}{"sku": "pen-7"},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"product": map[string]interface{}{"sku": "book-1", "related": "book-2"},
+		"nextSKU": "pen-8",
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type PhoneNumber string
+
+type CallInput struct {
+	Number PhoneNumber `gql:"number,nonNull"`
+}
+
+type PhoneHost struct{}
+
+func (h *PhoneHost) Call(input CallInput) (PhoneNumber, error) {
+	return input.Number, nil
+}
+
+// normalizePhone keeps the digits of a phone number
+func normalizePhone(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+	return PhoneNumber("+" + digits)
+}
+
+func TestWithScalar(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithScalar(reflect.TypeOf(PhoneNumber("")), graphql.ScalarConfig{
+			Name: "PhoneNumber",
+			Serialize: func(value interface{}) interface{} {
+				return "tel:" + string(value.(PhoneNumber))
+			},
+			ParseValue: normalizePhone,
+		}).
+		WithQuery(&PhoneHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, params := range []graphql.Params{
+		{RequestString: `{ call(number: "1 (555) 123-4567") }`},
+		{
+			RequestString:  `query($number: PhoneNumber!) { call(number: $number) }`,
+			VariableValues: map[string]interface{}{"number": "1-555-123-4567"},
+		},
+	} {
+		params.Schema = *schema
+		params.Context = context.Background()
+		result := graphql.Do(params)
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+
+		expected := map[string]interface{}{"call": "tel:+15551234567"}
+		if !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("expected %v, got %v", expected, result.Data)
+		}
+	}
+}
+
+type AttributesInput struct {
+	Attributes map[string]interface{} `gql:"attributes"`
+}
+
+type AttributesHost struct{}
+
+func (h *AttributesHost) Describe(input AttributesInput) (string, error) {
+	return fmt.Sprint(input.Attributes), nil
+}
+
+func TestMapArgumentAsJSON(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&AttributesHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if argType := schema.QueryType().Fields()["describe"].Args[0].Type.String(); argType != "JSON" {
+		t.Fatalf("expected JSON argument, got %s", argType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($attributes: JSON) { literal: describe(attributes: {color: "red", size: {w: 2}}) variable: describe(attributes: $attributes) }`,
+		VariableValues: map[string]interface{}{"attributes": map[string]interface{}{"tags": []interface{}{"a"}}},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"literal":  "map[color:red size:map[w:2]]",
+		"variable": "map[tags:[a]]",
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type CustomID string
+
+type Reference struct {
+	ID     CustomID  `gql:"id"`
+	Parent *CustomID `gql:"parent"`
+	Alias  *CustomID `gql:"alias"`
+}
+
+type ReferenceHost struct{}
+
+func (h *ReferenceHost) Reference() (*Reference, error) {
+	parent := CustomID("p1")
+	return &Reference{ID: "r1", Parent: &parent}, nil
+}
+
+func TestPointerToNamedScalar(t *testing.T) {
+	b := NewSchemaBuilder()
+	b.RegisterCustomType(reflect.TypeOf(CustomID("")), graphql.ID)
+	schema, err := b.WithQuery(&ReferenceHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := schema.Type("Reference").(*graphql.Object).Fields()
+	if fieldType := fields["parent"].Type.String(); fieldType != "ID" {
+		t.Fatalf("expected ID, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ reference { id parent alias } }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"reference": map[string]interface{}{"id": "r1", "parent": "p1", "alias": nil},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type Price struct {
+	Amount json.Number `gql:"amount"`
+}
+
+type PriceInput struct {
+	Amount json.Number `gql:"amount,nonNull"`
+}
+
+type PriceHost struct{}
+
+func (h *PriceHost) Price(input PriceInput) (*Price, error) {
+	return &Price{Amount: input.Amount}, nil
+}
+
+func TestJSONNumber(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&PriceHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if fieldType := schema.Type("Price").(*graphql.Object).Fields()["amount"].Type.String(); fieldType != "Number" {
+		t.Fatalf("expected Number, got %s", fieldType)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($amount: Number!) { literal: price(amount: 12345678901234567.89) { amount } variable: price(amount: $amount) { amount } }`,
+		VariableValues: map[string]interface{}{"amount": 1.5},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"literal":  map[string]interface{}{"amount": json.Number("12345678901234567.89")},
+		"variable": map[string]interface{}{"amount": json.Number("1.5")},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+
+	body, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(string(body), `"amount":12345678901234567.89`) {
+		t.Fatalf("expected exact number in %s", body)
+	}
+}
+
+func TestJSONNumberRejectsInvalidNumbers(t *testing.T) {
+	for _, value := range []interface{}{"NaN", "Inf", "-Inf", "0x1p-2", "1_0", "+1", "01", "1.", ".5", "", json.Number("NaN"), math.NaN(), math.Inf(1)} {
+		if number := coerceJSONNumber(value); number != nil {
+			t.Errorf("expected %#v to be rejected, got %v", value, number)
+		}
+	}
+	for _, value := range []string{"0", "-0", "42", "-1.5", "1e10", "2.5E-3"} {
+		if number := coerceJSONNumber(value); number != json.Number(value) {
+			t.Errorf("expected %s to be accepted, got %v", value, number)
+		}
+	}
+
+	schema, err := NewSchemaBuilder().WithQuery(&PriceHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  `query($amount: Number!) { price(amount: $amount) { amount } }`,
+		VariableValues: map[string]interface{}{"amount": "NaN"},
+		Context:        context.Background(),
+	})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "Variable \"$amount\" got invalid value") {
+		t.Fatalf("expected an invalid value error, got %v", result.Errors)
+	}
+}
+
+func TestDecodeJSONNumber(t *testing.T) {
+	argInfo := NewArgInfo(reflect.TypeOf(PriceInput{}), 1)
+	value, err := argInfo.ValueFromMap(map[string]interface{}{"amount": 42})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if amount := value.Interface().(PriceInput).Amount; amount != "42" {
+		t.Fatalf("expected 42, got %q", amount)
+	}
+}
+
+type Kelvin float64
+
+type Fahrenheit = float64
+
+type Reading struct {
+	Named   Kelvin     `gql:"named"`
+	Alias   Fahrenheit `gql:"alias"`
+	History []Kelvin   `gql:"history"`
+	Peaks   []*Kelvin  `gql:"peaks"`
+}
+
+type ReadingInput struct {
+	Named   Kelvin     `gql:"named"`
+	Alias   Fahrenheit `gql:"alias"`
+	History []Kelvin   `gql:"history"`
+}
+
+type ReadingHost struct{}
+
+func (h *ReadingHost) Reading(input ReadingInput) (*Reading, error) {
+	peak := input.Named + 1
+	return &Reading{Named: input.Named, Alias: input.Alias, History: input.History, Peaks: []*Kelvin{&peak, nil}}, nil
+}
+
+func (h *ReadingHost) Warmest() (Kelvin, error) {
+	return 31.5, nil
+}
+
+func (h *ReadingHost) Forecast() ([][]Kelvin, error) {
+	return [][]Kelvin{{1.5, 2.5}, {3.5}}, nil
+}
+
+func TestNamedAndAliasNumericTypes(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ReadingHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, arg := range schema.QueryType().Fields()["reading"].Args {
+		if graphql.GetNamed(arg.Type) != graphql.Float {
+			t.Fatalf("expected Float argument %s, got %s", arg.Name(), arg.Type)
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ reading(named: 21.5, alias: 70.7, history: [20.5, 19.5]) { named alias history peaks } warmest forecast }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{
+		"reading": map[string]interface{}{
+			"named":   21.5,
+			"alias":   70.7,
+			"history": []interface{}{20.5, 19.5},
+			"peaks":   []interface{}{22.5, nil},
+		},
+		"warmest":  31.5,
+		"forecast": []interface{}{[]interface{}{1.5, 2.5}, []interface{}{3.5}},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
@@ -0,0 +1,252 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// builtinScalars are the scalars every schema contains, omitted from SDL
+var builtinScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// sdlMetadata holds what graphql-go types can't carry, recorded by a builder
+type sdlMetadata struct {
+	specifiedByURLs map[*graphql.Scalar]string
+	typeExtensions  map[*graphql.Object][]string
+	oneOfInputs     map[*graphql.InputObject]bool
+}
+
+// PrintSchema exports the schema in the GraphQL schema definition language.
+// Types are printed in name order, introspection types and built-in scalars
+// are omitted.
+func PrintSchema(schema *graphql.Schema) string {
+	return printSchema(schema, &sdlMetadata{})
+}
+
+// PrintSchema is like the package level PrintSchema, also printing the
+// @specifiedBy directives of WithSpecifiedBy, the extend type blocks of
+// ExtendType and the @oneOf directives of oneOf inputs
+func (b *SchemaBuilder) PrintSchema(schema *graphql.Schema) string {
+	return printSchema(schema, &sdlMetadata{
+		specifiedByURLs: b.specifiedByURLs,
+		typeExtensions:  b.typeExtensions,
+		oneOfInputs:     b.oneOfInputs,
+	})
+}
+
+func printSchema(schema *graphql.Schema, metadata *sdlMetadata) string {
+	blocks := []string{}
+
+	if block := printSchemaDefinition(schema); block != "" {
+		blocks = append(blocks, block)
+	}
+
+	typeMap := schema.TypeMap()
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		if strings.HasPrefix(name, "__") || builtinScalars[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if block := metadata.printType(typeMap[name]); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// printSchemaDefinition prints the schema block if root types aren't named
+// by convention
+func printSchemaDefinition(schema *graphql.Schema) string {
+	roots := []struct {
+		operation string
+		object    *graphql.Object
+		name      RootType
+	}{
+		{"query", schema.QueryType(), Query},
+		{"mutation", schema.MutationType(), Mutation},
+		{"subscription", schema.SubscriptionType(), Subscription},
+	}
+
+	conventional := true
+	lines := []string{}
+	for _, root := range roots {
+		if root.object == nil {
+			continue
+		}
+		if root.object.Name() != string(root.name) {
+			conventional = false
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", root.operation, root.object.Name()))
+	}
+
+	if conventional {
+		return ""
+	}
+	return "schema {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+func (m *sdlMetadata) printType(t graphql.Type) string {
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		line := printDescription(t.Description(), "") + "scalar " + t.Name()
+		if url, ok := m.specifiedByURLs[t]; ok {
+			line += " @specifiedBy(url: " + strconv.Quote(url) + ")"
+		}
+		return line
+	case *graphql.Enum:
+		lines := []string{}
+		for _, value := range t.Values() {
+			lines = append(lines, printDescription(value.Description, "  ")+"  "+value.Name+printDeprecated(value.DeprecationReason))
+		}
+		sortByName(lines)
+		return printDescription(t.Description(), "") + "enum " + t.Name() + " " + printBlock(lines)
+	case *graphql.InputObject:
+		lines := []string{}
+		for _, field := range t.Fields() {
+			line := printDescription(field.Description(), "  ") + "  " + field.Name() + ": " + field.Type.String()
+			line += printDefault(field.DefaultValue)
+			lines = append(lines, line)
+		}
+		sortByName(lines)
+		header := "input " + t.Name()
+		if m.oneOfInputs[t] {
+			header += " @oneOf"
+		}
+		return printDescription(t.Description(), "") + header + " " + printBlock(lines)
+	case *graphql.Object:
+		header := "type " + t.Name()
+		if interfaces := t.Interfaces(); len(interfaces) > 0 {
+			names := []string{}
+			for _, iface := range interfaces {
+				names = append(names, iface.Name())
+			}
+			header += " implements " + strings.Join(names, " & ")
+		}
+		fields, extension := m.splitExtensionFields(t)
+		block := printDescription(t.Description(), "") + header + " " + printBlock(printFields(fields))
+		if len(extension) > 0 {
+			block += "\n\nextend type " + t.Name() + " " + printBlock(printFields(extension))
+		}
+		return block
+	case *graphql.Interface:
+		return printDescription(t.Description(), "") + "interface " + t.Name() + " " + printBlock(printFields(t.Fields()))
+	case *graphql.Union:
+		names := []string{}
+		for _, member := range t.Types() {
+			names = append(names, member.Name())
+		}
+		return printDescription(t.Description(), "") + "union " + t.Name() + " = " + strings.Join(names, " | ")
+	}
+	return ""
+}
+
+// splitExtensionFields separates the fields added to object by ExtendType from
+// the ones it was built with
+func (m *sdlMetadata) splitExtensionFields(object *graphql.Object) (graphql.FieldDefinitionMap, graphql.FieldDefinitionMap) {
+	fields := graphql.FieldDefinitionMap{}
+	for name, field := range object.Fields() {
+		fields[name] = field
+	}
+	extension := graphql.FieldDefinitionMap{}
+	for _, name := range m.typeExtensions[object] {
+		extension[name] = fields[name]
+		delete(fields, name)
+	}
+	return fields, extension
+}
+
+func printFields(fields graphql.FieldDefinitionMap) []string {
+	lines := []string{}
+	for _, field := range fields {
+		line := printDescription(field.Description, "  ") + "  " + field.Name
+		if len(field.Args) > 0 {
+			args := []string{}
+			for _, arg := range field.Args {
+				args = append(args, arg.Name()+": "+arg.Type.String()+printDefault(arg.DefaultValue))
+			}
+			sort.Strings(args)
+			line += "(" + strings.Join(args, ", ") + ")"
+		}
+		line += ": " + field.Type.String() + printDeprecated(field.DeprecationReason)
+		lines = append(lines, line)
+	}
+	sortByName(lines)
+	return lines
+}
+
+// sortByName sorts field lines by field name, ignoring leading descriptions
+func sortByName(lines []string) {
+	name := func(line string) string {
+		if i := strings.LastIndex(line, "\"\"\"\n"); i >= 0 {
+			line = line[i+4:]
+		}
+		return strings.TrimSpace(line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return name(lines[i]) < name(lines[j])
+	})
+}
+
+func printBlock(lines []string) string {
+	return "{\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+func printDescription(description string, indent string) string {
+	if description == "" {
+		return ""
+	}
+	return indent + `"""` + "\n" + indent + description + "\n" + indent + `"""` + "\n"
+}
+
+func printDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return " @deprecated(reason: " + strconv.Quote(reason) + ")"
+}
+
+func printDefault(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return " = " + printValue(value)
+}
+
+// printValue prints a Go value as a GraphQL literal
+func printValue(value interface{}) string {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Slice, reflect.Array:
+		items := []string{}
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, printValue(v.Index(i).Interface()))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map:
+		items := []string{}
+		for _, key := range v.MapKeys() {
+			items = append(items, fmt.Sprintf("%v: %s", key.Interface(), printValue(v.MapIndex(key).Interface())))
+		}
+		sort.Strings(items)
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprintf("%v", value)
+}
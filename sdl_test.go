@@ -0,0 +1,89 @@
+package gql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type SDLUser struct {
+	ID     string `gql:"id,nonNull"`
+	Name   string `gql:"name"`
+	Status Status `gql:"status"`
+}
+
+type SDLUserInput struct {
+	ID string `gql:"id,nonNull"`
+}
+
+type SDLQuery struct{}
+
+func (q *SDLQuery) User(input SDLUserInput) (*SDLUser, error) {
+	return &SDLUser{ID: input.ID}, nil
+}
+
+func TestPrintSchema(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithEnum(reflect.TypeOf(Status(0)), "Status", map[string]interface{}{"INACTIVE": 0, "ACTIVE": 1}).
+		WithQuery(&SDLQuery{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := `schema {
+  query: SDLQuery
+}
+
+type SDLQuery {
+  user(id: String!): SDLUser
+}
+
+type SDLUser {
+  id: String!
+  name: String
+  status: Status
+}
+
+enum Status {
+  ACTIVE
+  INACTIVE
+}
+`
+	if actual := PrintSchema(schema); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+type UUID string
+
+type SDLNode struct {
+	ID UUID `gql:"id"`
+}
+
+type SDLNodeQuery struct{}
+
+func (q *SDLNodeQuery) Node() (*SDLNode, error) {
+	return &SDLNode{ID: "a"}, nil
+}
+
+func TestPrintSpecifiedBy(t *testing.T) {
+	uuid := graphql.NewScalar(graphql.ScalarConfig{
+		Name:      "UUID",
+		Serialize: func(value interface{}) interface{} { return value },
+	})
+
+	b := NewSchemaBuilder().WithSpecifiedBy(uuid, "https://tools.ietf.org/html/rfc4122")
+	b.RegisterCustomType(reflect.TypeOf(UUID("")), uuid)
+	schema, err := b.WithQuery(&SDLNodeQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := `scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")`
+	if sdl := b.PrintSchema(schema); !strings.Contains(sdl, expected) {
+		t.Fatalf("expected %s in:\n%s", expected, sdl)
+	}
+}
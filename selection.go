@@ -0,0 +1,79 @@
+package gql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// SelectedFields returns the names of the fields the client selected on the
+// result of the resolved field, in selection order, such as the columns to
+// load from a database. Fields of fragments are included, fields excluded by
+// the @skip or @include directives aren't.
+func SelectedFields(info graphql.ResolveInfo) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, field := range info.FieldASTs {
+		collectSelectedFields(info, field.SelectionSet, seen, &names)
+	}
+	return names
+}
+
+func collectSelectedFields(info graphql.ResolveInfo, selectionSet *ast.SelectionSet, seen map[string]bool, names *[]string) {
+	if selectionSet == nil {
+		return
+	}
+
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if !isIncluded(info, selection.Directives) || seen[selection.Name.Value] {
+				continue
+			}
+			seen[selection.Name.Value] = true
+			*names = append(*names, selection.Name.Value)
+		case *ast.InlineFragment:
+			if isIncluded(info, selection.Directives) {
+				collectSelectedFields(info, selection.SelectionSet, seen, names)
+			}
+		case *ast.FragmentSpread:
+			fragment, ok := info.Fragments[selection.Name.Value].(*ast.FragmentDefinition)
+			if ok && isIncluded(info, selection.Directives) {
+				collectSelectedFields(info, fragment.SelectionSet, seen, names)
+			}
+		}
+	}
+}
+
+// isIncluded evaluates the @skip and @include directives of a selection
+func isIncluded(info graphql.ResolveInfo, directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		switch directive.Name.Value {
+		case graphql.SkipDirective.Name:
+			if directiveCondition(info, directive) {
+				return false
+			}
+		case graphql.IncludeDirective.Name:
+			if !directiveCondition(info, directive) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// directiveCondition returns the value of the if argument of a directive
+func directiveCondition(info graphql.ResolveInfo, directive *ast.Directive) bool {
+	for _, arg := range directive.Arguments {
+		if arg.Name.Value != "if" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.BooleanValue:
+			return value.Value
+		case *ast.Variable:
+			condition, _ := info.VariableValues[value.Name.Value].(bool)
+			return condition
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type SelectionUser struct {
+	ID    string `gql:"id"`
+	Name  string `gql:"name"`
+	Email string `gql:"email"`
+	Phone string `gql:"phone"`
+}
+
+type SelectionHost struct {
+	selected []string
+}
+
+func (h *SelectionHost) User(info graphql.ResolveInfo) (*SelectionUser, error) {
+	h.selected = SelectedFields(info)
+	return &SelectionUser{ID: "u1"}, nil
+}
+
+func TestSelectedFields(t *testing.T) {
+	host := &SelectionHost{}
+	schema, err := NewSchemaBuilder().WithQuery(host).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: *schema,
+		RequestString: `query($withEmail: Boolean!) {
+			user {
+				id
+				name @skip(if: true)
+				email @include(if: $withEmail)
+				... on SelectionUser { id phone }
+				...contact @skip(if: false)
+			}
+		}
+		fragment contact on SelectionUser { name @include(if: false) phone }`,
+		VariableValues: map[string]interface{}{"withEmail": true},
+		Context:        context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := []string{"id", "email", "phone"}
+	if !reflect.DeepEqual(host.selected, expected) {
+		t.Fatalf("expected %v, got %v", expected, host.selected)
+	}
+}
@@ -0,0 +1,73 @@
+package gql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Stitch merges the root fields of already built schemas into a single schema.
+// Root objects are named Query, Mutation and Subscription; a root field
+// defined by more than one schema is reported as a conflict. The placeholder
+// Query fields of schemas without queries are left out.
+func Stitch(schemas ...*graphql.Schema) (*graphql.Schema, error) {
+	queryFields := graphql.Fields{}
+	mutationFields := graphql.Fields{}
+	subscriptionFields := graphql.Fields{}
+
+	for _, schema := range schemas {
+		if err := stitchFields(queryFields, schema.QueryType()); err != nil {
+			return nil, err
+		}
+		if err := stitchFields(mutationFields, schema.MutationType()); err != nil {
+			return nil, err
+		}
+		if err := stitchFields(subscriptionFields, schema.SubscriptionType()); err != nil {
+			return nil, err
+		}
+	}
+
+	queryObject := stitchedObject(string(Query), queryFields)
+	if queryObject == nil {
+		// Schemas without queries still need a Query object
+		queryObject = placeholderObject(string(Query))
+	}
+
+	schemaConfig := graphql.SchemaConfig{
+		Query:        queryObject,
+		Mutation:     stitchedObject(string(Mutation), mutationFields),
+		Subscription: stitchedObject(string(Subscription), subscriptionFields),
+	}
+
+	schema, err := graphql.NewSchema(schemaConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// stitchFields copies the field definitions of object into fields
+func stitchFields(fields graphql.Fields, object *graphql.Object) error {
+	if object == nil {
+		return nil
+	}
+
+	for name, field := range ObjectFields(object) {
+		if _, ok := fields[name]; ok {
+			return fmt.Errorf("conflicting root field %s on %s", name, object.Name())
+		}
+		fields[name] = field
+	}
+	return nil
+}
+
+// stitchedObject creates a root object from fields, or nil if there are none
+func stitchedObject(name string, fields graphql.Fields) *graphql.Object {
+	if len(fields) == 0 {
+		return nil
+	}
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+}
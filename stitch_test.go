@@ -0,0 +1,113 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type UsersQuery struct{}
+
+func (q *UsersQuery) UserName() (string, error) {
+	return "john", nil
+}
+
+type PostsQuery struct{}
+
+func (q *PostsQuery) PostTitle() (string, error) {
+	return "hello", nil
+}
+
+func TestStitch(t *testing.T) {
+	users, err := NewSchemaBuilder().WithQuery(&UsersQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	posts, err := NewSchemaBuilder().WithQuery(&PostsQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	schema, err := Stitch(users, posts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ userName postTitle }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+
+	expected := map[string]interface{}{"userName": "john", "postTitle": "hello"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestStitchConflict(t *testing.T) {
+	first, err := NewSchemaBuilder().WithQuery(&UsersQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	second, err := NewSchemaBuilder().WithQuery(&UsersQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := Stitch(first, second); err == nil {
+		t.Fatalf("expected conflict error, got nil")
+	}
+}
+
+type AlertsHost struct{}
+
+func (h *AlertsHost) Alerts(ctx context.Context) (<-chan string, error) {
+	alerts := make(chan string)
+	close(alerts)
+	return alerts, nil
+}
+
+func TestStitchSubscriptionOnlySchemas(t *testing.T) {
+	ticker, err := NewSchemaBuilder().WithSubscription(&TickerHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	alerts, err := NewSchemaBuilder().WithSubscription(&AlertsHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	schema, err := Stitch(ticker, alerts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := schema.QueryType().Fields()[PlaceholderFieldName]; !ok {
+		t.Fatalf("expected a placeholder query field, got %v", schema.QueryType().Fields())
+	}
+	if fields := schema.SubscriptionType().Fields(); len(fields) != 2 {
+		t.Fatalf("expected 2 subscription fields, got %v", fields)
+	}
+
+	// Placeholders are left out next to real query fields
+	users, err := NewSchemaBuilder().WithQuery(&UsersQuery{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	schema, err = Stitch(ticker, users)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sdl := PrintSchema(schema); strings.Contains(sdl, PlaceholderFieldName) {
+		t.Fatalf("expected no placeholder field, got %s", sdl)
+	}
+}
@@ -0,0 +1,79 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// channelAsGraphqlField builds a subscription field for a resolver returning a
+// channel. The field is typed after the channel elements, each element
+// received is resolved as an event of the subscription.
+func (b *SchemaBuilder) channelAsGraphqlField(fieldName string, resolveInfo *ResolveInfo) (*graphql.Field, error) {
+	graphqlField, err := b.TypeAsGraphqlField(resolveInfo.Output.Type.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := b.fieldCaches[fieldName]; ok {
+		return nil, fmt.Errorf("subscription field %s can't be cached, its channel would be shared by subscribers", fieldName)
+	}
+
+	graphqlField.Name = fieldName
+	// Middlewares, validators and panic handlers apply to the call opening the
+	// channel, events are forwarded until the subscription's context is done
+	resolve := b.wrapResolver(fieldName, resolveInfo.Resolve)
+	graphqlField.Subscribe = func(p graphql.ResolveParams) (interface{}, error) {
+		result, err := resolve(p)
+		if err != nil {
+			return nil, err
+		}
+		return forwardChannel(p.Context, reflect.ValueOf(result)), nil
+	}
+	// Events are the sources of the subscription field
+	graphqlField.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source, nil
+	}
+	if err := b.populateResolverArgs(graphqlField, resolveInfo); err != nil {
+		return nil, err
+	}
+	return graphqlField, nil
+}
+
+// forwardChannel forwards the elements of the typed channel source to the
+// untyped channel graphql-go subscribes to. The returned channel is closed
+// once source is closed, including when it's already closed or nil, or when
+// ctx is done.
+func forwardChannel(ctx context.Context, source reflect.Value) chan interface{} {
+	out := make(chan interface{})
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	go func() {
+		defer close(out)
+		if !source.IsValid() || source.IsNil() {
+			return
+		}
+
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: source},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
+		for {
+			chosen, value, ok := reflect.Select(cases)
+			if chosen == 1 || !ok {
+				return
+			}
+			select {
+			case out <- valueInterface(value):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
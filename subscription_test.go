@@ -0,0 +1,212 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type TickerHost struct {
+	Values []int
+}
+
+func (h *TickerHost) Ticks(ctx context.Context) (<-chan int, error) {
+	ticks := make(chan int, len(h.Values))
+	for _, tick := range h.Values {
+		ticks <- tick
+	}
+	close(ticks)
+	return ticks, nil
+}
+
+type TickerQuery struct{}
+
+func (q *TickerQuery) Ping() (string, error) {
+	return "pong", nil
+}
+
+func subscribeTicks(t *testing.T, ticks []int) []*graphql.Result {
+	schema, err := NewSchemaBuilder().
+		WithQuery(&TickerQuery{}).
+		WithSubscription(&TickerHost{Values: ticks}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results := []*graphql.Result{}
+	events := graphql.Subscribe(graphql.Params{
+		Schema:        *schema,
+		RequestString: `subscription { ticks }`,
+		Context:       ctx,
+	})
+	for {
+		select {
+		case result, ok := <-events:
+			if !ok {
+				return results
+			}
+			results = append(results, result)
+		case <-ctx.Done():
+			t.Fatalf("subscription did not complete")
+		}
+	}
+}
+
+func TestSubscriptionChannel(t *testing.T) {
+	results := subscribeTicks(t, []int{1, 2})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, result := range results {
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+		data := result.Data.(map[string]interface{})
+		if data["ticks"] != i+1 {
+			t.Fatalf("expected %d, got %v", i+1, data["ticks"])
+		}
+	}
+}
+
+func TestSubscriptionClosedChannel(t *testing.T) {
+	results := subscribeTicks(t, nil)
+	if len(results) != 0 {
+		t.Fatalf("expected no results, got %v", results)
+	}
+}
+
+func TestForwardNilChannel(t *testing.T) {
+	var source chan int
+	select {
+	case _, ok := <-forwardChannel(context.Background(), reflect.ValueOf(source)):
+		if ok {
+			t.Fatalf("expected closed channel")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected forwarded channel to be closed")
+	}
+}
+
+func TestSubscriptionOnlySchema(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithSubscription(&TickerHost{Values: []int{1, 2}}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, ok := schema.QueryType().Fields()[PlaceholderFieldName]; !ok {
+		t.Fatalf("expected placeholder query field, got %v", schema.QueryType().Fields())
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ _placeholder }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+	if expected := map[string]interface{}{"_placeholder": nil}; !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ticks := []interface{}{}
+	for result := range graphql.Subscribe(graphql.Params{
+		Schema:        *schema,
+		RequestString: `subscription { ticks }`,
+		Context:       ctx,
+	}) {
+		if result.Errors != nil {
+			t.Fatalf("expected no errors, got %v", result.Errors)
+		}
+		ticks = append(ticks, result.Data.(map[string]interface{})["ticks"])
+	}
+	if expected := []interface{}{1, 2}; !reflect.DeepEqual(ticks, expected) {
+		t.Fatalf("expected %v, got %v", expected, ticks)
+	}
+}
+
+type PanickingTickerHost struct{}
+
+func (h *PanickingTickerHost) Ticks(ctx context.Context) (<-chan int, error) {
+	panic("no ticker")
+}
+
+func subscriptionErrors(schema *graphql.Schema) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	messages := []string{}
+	for result := range graphql.Subscribe(graphql.Params{
+		Schema:        *schema,
+		RequestString: `subscription { ticks }`,
+		Context:       ctx,
+	}) {
+		for _, err := range result.Errors {
+			messages = append(messages, err.Message)
+		}
+	}
+	return messages
+}
+
+func TestContextMiddlewareRejectsSubscription(t *testing.T) {
+	schema, err := NewSchemaBuilder().
+		WithContextMiddleware(func(ctx context.Context, info graphql.ResolveInfo) (context.Context, error) {
+			return nil, errors.New("unauthenticated")
+		}).
+		WithQuery(&TickerQuery{}).
+		WithSubscription(&TickerHost{Values: []int{1}}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if messages := subscriptionErrors(schema); !reflect.DeepEqual(messages, []string{"unauthenticated"}) {
+		t.Fatalf("expected an unauthenticated error, got %v", messages)
+	}
+}
+
+func TestPanicHandlerRecoversSubscription(t *testing.T) {
+	recovered := []interface{}{}
+	schema, err := NewSchemaBuilder().
+		WithPanicHandler(func(value interface{}, p graphql.ResolveParams) {
+			recovered = append(recovered, value)
+		}).
+		WithQuery(&TickerQuery{}).
+		WithSubscription(&PanickingTickerHost{}).
+		BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if messages := subscriptionErrors(schema); len(messages) != 1 || !strings.Contains(messages[0], "no ticker") {
+		t.Fatalf("expected a panic error, got %v", messages)
+	}
+	if !reflect.DeepEqual(recovered, []interface{}{"no ticker"}) {
+		t.Fatalf("expected the panic to be handled, got %v", recovered)
+	}
+}
+
+func TestSubscriptionFieldCache(t *testing.T) {
+	_, err := NewSchemaBuilder().
+		WithFieldCache("ticks", time.Minute).
+		WithQuery(&TickerQuery{}).
+		WithSubscription(&TickerHost{}).
+		BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "subscription field ticks can't be cached") {
+		t.Fatalf("expected a cache error, got %v", err)
+	}
+}
@@ -3,6 +3,8 @@ package gql
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -10,34 +12,137 @@ const (
 	GqlTagKey = "gql"
 )
 
+// gqlTagOptions lists the key=value options accepted after the field name
+// along with a validator for their values
+var gqlTagOptions = map[string]func(value string) error{
+	"min":         validateFloatOption,
+	"max":         validateFloatOption,
+	"pattern":     validatePatternOption,
+	"cost":        validateIntOption,
+	"description": validateAnyOption,
+	"default":     validateAnyOption,
+	"from":        validateAnyOption,
+}
+
+func validateAnyOption(value string) error {
+	return nil
+}
+
+func validateFloatOption(value string) error {
+	_, err := strconv.ParseFloat(value, 64)
+	return err
+}
+
+func validateIntOption(value string) error {
+	_, err := strconv.Atoi(value)
+	return err
+}
+
+func validatePatternOption(value string) error {
+	_, err := regexp.Compile(value)
+	return err
+}
+
+// tagError reports an invalid gql tag of a struct field. Unlike signature
+// mismatches, which only skip a method, it fails the build.
+type tagError struct {
+	owner reflect.Type
+	field string
+	err   error
+}
+
+func (e *tagError) Error() string {
+	return fmt.Sprintf("Invalid gql tag of %s.%s: %v", e.owner.Name(), e.field, e.err)
+}
+
+func (e *tagError) Unwrap() error {
+	return e.err
+}
+
 type GqlTag struct {
 	FieldName string
 	NonNull   bool
+	Nullable  bool
+	// NonNullItems marks the elements of a list field non-null, e.g. [String!]
+	NonNullItems bool
+	// OneOf marks the struct as a oneOf input, set on a blank field
+	OneOf   bool
+	Options map[string]string
 }
 
 func (t *GqlTag) IsNonNull() bool {
 	return t.NonNull
 }
 
+// IsNonNullItems reports whether the elements of a list field are non-null
+func (t *GqlTag) IsNonNullItems() bool {
+	return t.NonNullItems
+}
+
+// IsNullable reports whether the field opts out of implicit non-null wrapping
+func (t *GqlTag) IsNullable() bool {
+	return t.Nullable
+}
+
 func (t *GqlTag) GetFieldName() string {
 	return t.FieldName
 }
 
+// Option returns the value of a key=value tag option
+func (t *GqlTag) Option(key string) (string, bool) {
+	value, ok := t.Options[key]
+	return value, ok
+}
+
 func ParseGqlTag(tag string) (*GqlTag, error) {
 	t := &GqlTag{}
 
 	parts := strings.Split(tag, ",")
-	if len(parts) > 2 {
-		return nil, fmt.Errorf("Invalid gql tag expected fieldName, got: %s", tag)
-	}
-
 	t.FieldName = parts[0]
-	if len(parts) == 2 {
-		if parts[1] == "nonNull" {
-			t.NonNull = true
-		} else {
-			return nil, fmt.Errorf("Invalid gql tag expected nonNull, got: %s", parts[1])
+
+	for i := 1; i < len(parts); i++ {
+		part := parts[i]
+		// Patterns may contain commas, such as ^[a-z]{1,3}$, so the pattern
+		// option takes the rest of the tag and must come last
+		if strings.HasPrefix(part, "pattern=") {
+			part = strings.Join(parts[i:], ",")
+			i = len(parts)
+		}
+
+		if part == "oneOf" {
+			t.OneOf = true
+			continue
+		}
+
+		if part == "nonNullItems" {
+			if t.NonNullItems {
+				return nil, fmt.Errorf("Invalid gql tag duplicate nonNullItems, got: %s", tag)
+			}
+			t.NonNullItems = true
+			continue
+		}
+
+		if part == "nonNull" || part == "nullable" {
+			if t.NonNull || t.Nullable {
+				return nil, fmt.Errorf("Invalid gql tag expected one of nonNull or nullable, got: %s", tag)
+			}
+			t.NonNull = part == "nonNull"
+			t.Nullable = part == "nullable"
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		validate, known := gqlTagOptions[key]
+		if !ok || !known {
+			return nil, fmt.Errorf("Invalid gql tag expected nonNull, nullable, nonNullItems, oneOf or option, got: %s", part)
+		}
+		if err := validate(value); err != nil {
+			return nil, fmt.Errorf("Invalid gql tag option %s: %w", part, err)
+		}
+		if t.Options == nil {
+			t.Options = map[string]string{}
 		}
+		t.Options[key] = value
 	}
 
 	return t, nil
@@ -183,3 +183,72 @@ func TestGetGqlTag(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGqlTagOptions(t *testing.T) {
+	cases := []struct {
+		tag             string
+		expectedOptions map[string]string
+		expectedNonNull bool
+		expectedError   bool
+	}{
+		{"age,min=0,max=150", map[string]string{"min": "0", "max": "150"}, false, false},
+		{"age,nonNull,min=0", map[string]string{"min": "0"}, true, false},
+		{"code,pattern=^[a-z]+$", map[string]string{"pattern": "^[a-z]+$"}, false, false},
+		{"limit,default=10,description=Page size", map[string]string{"default": "10", "description": "Page size"}, false, false},
+		{"age,min=abc", nil, false, true},
+		{"code,pattern=[", nil, false, true},
+		{"code,nonNull,pattern=^[a-z]{1,3}$", map[string]string{"pattern": "^[a-z]{1,3}$"}, true, false},
+		{"code,pattern=^[a-z]{1,3}$,min=1", map[string]string{"pattern": "^[a-z]{1,3}$,min=1"}, false, false},
+		{"age,foo=1", nil, false, true},
+		{"age,nonNull,nonNull", nil, false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.tag, func(t *testing.T) {
+			gqlTag, err := ParseGqlTag(c.tag)
+			if err != nil != c.expectedError {
+				t.Fatalf("expected error to be %t, got %v", c.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(gqlTag.Options, c.expectedOptions) {
+				t.Fatalf("expected options %v, got %v", c.expectedOptions, gqlTag.Options)
+			}
+
+			if gqlTag.IsNonNull() != c.expectedNonNull {
+				t.Fatalf("expected nonNull to be %t, got %t", c.expectedNonNull, gqlTag.IsNonNull())
+			}
+		})
+	}
+}
+
+func TestParseGqlTagNullable(t *testing.T) {
+	gqlTag, err := ParseGqlTag("users,nullable")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gqlTag.IsNullable() || gqlTag.IsNonNull() {
+		t.Fatalf("expected nullable only, got %+v", gqlTag)
+	}
+
+	if _, err := ParseGqlTag("users,nullable,nonNull"); err == nil {
+		t.Fatalf("expected error for conflicting modifiers")
+	}
+}
+
+func TestParseGqlTagNonNullItems(t *testing.T) {
+	gqlTag, err := ParseGqlTag("tags,nonNull,nonNullItems")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gqlTag.IsNonNull() || !gqlTag.IsNonNullItems() {
+		t.Fatalf("expected nonNull and nonNullItems, got %+v", gqlTag)
+	}
+
+	if _, err := ParseGqlTag("tags,nonNullItems,nonNullItems"); err == nil {
+		t.Fatalf("expected error for duplicate nonNullItems")
+	}
+}
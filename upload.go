@@ -0,0 +1,183 @@
+package gql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DefaultMaxUploadSize is the default maximum size of multipart requests
+const DefaultMaxUploadSize = 32 << 20
+
+// uploadMemory is the size of the files of a multipart request kept in
+// memory, larger files are stored in temporary files
+const uploadMemory = 1 << 20
+
+// Upload is a file sent through a GraphQL multipart request, see
+// https://github.com/jaydenseric/graphql-multipart-request-spec. Resolver
+// inputs receive it in Upload or *Upload fields.
+type Upload struct {
+	File        io.Reader
+	Filename    string
+	ContentType string
+	Size        int64
+}
+
+// UploadScalar is the scalar of Upload arguments. Its values are only
+// provided by the files of multipart requests, never by literals.
+var UploadScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Upload",
+	Description: "Upload scalar type (file of a multipart request)",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case Upload:
+			return v.Filename
+		case *Upload:
+			if v == nil {
+				return nil
+			}
+			return v.Filename
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case Upload:
+			return &v
+		case *Upload:
+			return v
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return nil
+	},
+})
+
+// WithMaxUploadSize limits the size of multipart requests in bytes,
+// DefaultMaxUploadSize by default. Larger requests are rejected with 413.
+func WithMaxUploadSize(size int64) HandlerOption {
+	return func(h *Handler) {
+		h.maxUploadSize = size
+	}
+}
+
+// isMultipartRequest reports whether r is a multipart form request
+func isMultipartRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data")
+}
+
+// parseMultipartOptions reads the operations and map fields of a multipart
+// request, placing its files into the variables as *Upload values. The
+// returned files are to be closed, and the temporary files of the form
+// removed, once the request is served.
+func parseMultipartOptions(r *http.Request) (*RequestOptions, []multipart.File, error) {
+	if err := r.ParseMultipartForm(uploadMemory); err != nil {
+		return nil, nil, err
+	}
+
+	opts := &RequestOptions{}
+	if err := json.Unmarshal([]byte(r.FormValue("operations")), opts); err != nil {
+		return nil, nil, fmt.Errorf("invalid operations field: %w", err)
+	}
+
+	fileMap := map[string][]string{}
+	if fileMapField := r.FormValue("map"); fileMapField != "" {
+		if err := json.Unmarshal([]byte(fileMapField), &fileMap); err != nil {
+			return nil, nil, fmt.Errorf("invalid map field: %w", err)
+		}
+	}
+
+	files := []multipart.File{}
+	for key, paths := range fileMap {
+		headers := r.MultipartForm.File[key]
+		if len(headers) == 0 {
+			closeFiles(files)
+			return nil, nil, fmt.Errorf("missing file %s", key)
+		}
+		file, err := headers[0].Open()
+		if err != nil {
+			closeFiles(files)
+			return nil, nil, err
+		}
+		files = append(files, file)
+
+		upload := &Upload{
+			File:        file,
+			Filename:    headers[0].Filename,
+			ContentType: headers[0].Header.Get("Content-Type"),
+			Size:        headers[0].Size,
+		}
+		for _, path := range paths {
+			if err := setUploadVariable(opts, path, upload); err != nil {
+				closeFiles(files)
+				return nil, nil, err
+			}
+		}
+	}
+	return opts, files, nil
+}
+
+// setUploadVariable places upload at a path of the map field, such as
+// variables.file or variables.files.0
+func setUploadVariable(opts *RequestOptions, path string, upload *Upload) error {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 || parts[0] != "variables" || opts.Variables == nil {
+		return fmt.Errorf("invalid file path %s", path)
+	}
+
+	var container interface{} = opts.Variables
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		switch c := container.(type) {
+		case map[string]interface{}:
+			if last {
+				c[part] = upload
+				return nil
+			}
+			container = c[part]
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(c) {
+				return fmt.Errorf("invalid file path %s", path)
+			}
+			if last {
+				c[index] = upload
+				return nil
+			}
+			container = c[index]
+		default:
+			return fmt.Errorf("invalid file path %s", path)
+		}
+	}
+	return nil
+}
+
+// removeMultipartFiles removes the temporary files of a parsed multipart form
+func removeMultipartFiles(r *http.Request) {
+	if r.MultipartForm != nil {
+		r.MultipartForm.RemoveAll()
+	}
+}
+
+// closeFiles closes the files opened for uploads
+func closeFiles(files []multipart.File) {
+	for _, file := range files {
+		file.Close()
+	}
+}
+
+// isRequestTooLarge reports whether err was raised by exceeding the maximum
+// request size
+func isRequestTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr) || errors.Is(err, multipart.ErrMessageTooLarge)
+}
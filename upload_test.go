@@ -0,0 +1,136 @@
+package gql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type UploadInput struct {
+	File *Upload `gql:"file,nonNull"`
+}
+
+type UploadMutation struct{}
+
+func (m *UploadMutation) Upload(input UploadInput) (string, error) {
+	content, err := io.ReadAll(input.File.File)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s", input.File.Filename, content), nil
+}
+
+func newUploadRequest(t *testing.T, content string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("operations", `{"query": "mutation($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}`)
+	writer.WriteField("map", `{"0": ["variables.file"]}`)
+	part, err := writer.CreateFormFile("0", "notes.txt")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	part.Write([]byte(content))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func newUploadHandler(t *testing.T, opts ...HandlerOption) *Handler {
+	schema, err := NewSchemaBuilder().WithQuery(&HandlerQuery{}).WithMutation(&UploadMutation{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return NewHandler(schema, opts...)
+}
+
+func TestHandlerUpload(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newUploadHandler(t).ServeHTTP(rec, newUploadRequest(t, "hello"))
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("expected JSON response, got %s", rec.Body.String())
+	}
+
+	expected := map[string]interface{}{"data": map[string]interface{}{"upload": "notes.txt:hello"}}
+	if !reflect.DeepEqual(response, expected) {
+		t.Fatalf("expected %v, got %v", expected, response)
+	}
+}
+
+func TestHandlerMaxUploadSize(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newUploadHandler(t, WithMaxUploadSize(64)).ServeHTTP(rec, newUploadRequest(t, strings.Repeat("x", 1024)))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSetUploadVariable(t *testing.T) {
+	opts := &RequestOptions{Variables: map[string]interface{}{
+		"files": []interface{}{nil, nil},
+		"input": map[string]interface{}{"avatar": nil},
+	}}
+	upload := &Upload{Filename: "a.png"}
+
+	for _, path := range []string{"variables.files.1", "variables.input.avatar"} {
+		if err := setUploadVariable(opts, path, upload); err != nil {
+			t.Fatalf("expected no error for %s, got %v", path, err)
+		}
+	}
+	if opts.Variables["files"].([]interface{})[1] != upload {
+		t.Fatalf("expected upload in files list, got %v", opts.Variables["files"])
+	}
+	if opts.Variables["input"].(map[string]interface{})["avatar"] != upload {
+		t.Fatalf("expected upload in input, got %v", opts.Variables["input"])
+	}
+
+	for _, path := range []string{"variables.files.5", "operations.file", "variables"} {
+		if err := setUploadVariable(opts, path, upload); err == nil {
+			t.Fatalf("expected error for %s, got nil", path)
+		}
+	}
+}
+
+type SpilledUploadMutation struct {
+	names []string
+}
+
+func (m *SpilledUploadMutation) Upload(input UploadInput) (string, error) {
+	if file, ok := input.File.File.(*os.File); ok {
+		m.names = append(m.names, file.Name())
+	}
+	return input.File.Filename, nil
+}
+
+func TestHandlerUploadRemovesTemporaryFiles(t *testing.T) {
+	mutation := &SpilledUploadMutation{}
+	schema, err := NewSchemaBuilder().WithQuery(&HandlerQuery{}).WithMutation(mutation).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	NewHandler(schema).ServeHTTP(rec, newUploadRequest(t, strings.Repeat("x", 2*uploadMemory)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if len(mutation.names) != 1 {
+		t.Fatalf("expected the upload to be stored in a temporary file, got %v", mutation.names)
+	}
+	if _, err := os.Stat(mutation.names[0]); !os.IsNotExist(err) {
+		t.Fatalf("expected temporary file %s to be removed, got %v", mutation.names[0], err)
+	}
+}
@@ -0,0 +1,192 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// inputRules holds the validation options of the tagged fields of an input
+// struct type, parsed and compiled once when the resolver is built
+type inputRules struct {
+	oneOf  bool
+	fields []fieldRules
+}
+
+// fieldRules holds the validation options of one input field along with the
+// rules of its struct type, if any
+type fieldRules struct {
+	index   []int
+	name    string
+	min     string
+	max     string
+	pattern *regexp.Regexp
+	nested  *inputRules
+}
+
+// compileInputRules parses the gql tags of the input struct type t and its
+// nested input structs, compiling their patterns
+func compileInputRules(t reflect.Type) (*inputRules, error) {
+	return compileRules(t, map[reflect.Type]*inputRules{})
+}
+
+func compileRules(t reflect.Type, seen map[reflect.Type]*inputRules) (*inputRules, error) {
+	if rules, ok := seen[t]; ok {
+		return rules, nil
+	}
+	rules := &inputRules{oneOf: isOneOfInput(t)}
+	// Recursive input types share their rules
+	seen[t] = rules
+
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, err := ParseGqlTagFromField(&field)
+		if err != nil {
+			return nil, &tagError{owner: t, field: field.Name, err: err}
+		}
+		if tag.FieldName == "" || tag.FieldName == "-" {
+			continue
+		}
+
+		rule := fieldRules{index: field.Index, name: tag.FieldName}
+		rule.min, _ = tag.Option("min")
+		rule.max, _ = tag.Option("max")
+		if pattern, ok := tag.Option("pattern"); ok {
+			if rule.pattern, err = regexp.Compile(pattern); err != nil {
+				return nil, &tagError{owner: t, field: field.Name, err: err}
+			}
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			if rule.nested, err = compileRules(fieldType, seen); err != nil {
+				return nil, err
+			}
+		}
+		rules.fields = append(rules.fields, rule)
+	}
+	return rules, nil
+}
+
+// ValidateInput checks the decoded input value against the min, max and
+// pattern options of its gql tags. min and max bound numeric values and the
+// length of strings and slices, pattern must match string values. Exactly
+// one field of oneOf inputs must be set. All fields are validated, resolvers
+// only validate the fields present in their arguments.
+func ValidateInput(value reflect.Value) error {
+	t := value.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	rules, err := compileInputRules(t)
+	if err != nil {
+		return err
+	}
+	return rules.validate(value, nil)
+}
+
+// validate checks value against the rules. Fields are validated when their
+// key is present in args, or always when args is nil.
+func (rules *inputRules) validate(value reflect.Value, args map[string]interface{}) error {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if rules.oneOf {
+		if err := validateOneOf(value); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range rules.fields {
+		var fieldArgs map[string]interface{}
+		if args != nil {
+			// Omitted arguments decode to zero values, which are not validated
+			arg, ok := args[rule.name]
+			if !ok {
+				continue
+			}
+			fieldArgs, _ = arg.(map[string]interface{})
+			if fieldArgs == nil {
+				// Nested fields of non-object arguments, such as null, are absent
+				fieldArgs = map[string]interface{}{}
+			}
+		}
+
+		fieldValue := value.FieldByIndex(rule.index)
+		if err := rule.validate(fieldValue); err != nil {
+			return err
+		}
+
+		if rule.nested != nil {
+			if err := rule.nested.validate(fieldValue, fieldArgs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rule *fieldRules) validate(value reflect.Value) error {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	var measure float64
+	var subject string
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		measure, subject = float64(value.Int()), "value"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		measure, subject = float64(value.Uint()), "value"
+	case reflect.Float32, reflect.Float64:
+		measure, subject = value.Float(), "value"
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		measure, subject = float64(value.Len()), "length"
+	}
+
+	if subject != "" {
+		if rule.min != "" {
+			bound, _ := strconv.ParseFloat(rule.min, 64)
+			if measure < bound {
+				return fmt.Errorf("Invalid %s: %s %v is less than min %s", rule.name, subject, measure, rule.min)
+			}
+		}
+		if rule.max != "" {
+			bound, _ := strconv.ParseFloat(rule.max, 64)
+			if measure > bound {
+				return fmt.Errorf("Invalid %s: %s %v is greater than max %s", rule.name, subject, measure, rule.max)
+			}
+		}
+	}
+
+	if rule.pattern != nil && value.Kind() == reflect.String {
+		if !rule.pattern.MatchString(value.String()) {
+			return fmt.Errorf("Invalid %s: %q does not match pattern %s", rule.name, value.String(), rule.pattern)
+		}
+	}
+
+	return nil
+}
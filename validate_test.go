@@ -0,0 +1,140 @@
+package gql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type PersonInput struct {
+	Name string `gql:"name,nonNull,min=1,max=10"`
+	Age  int    `gql:"age,min=0,max=150"`
+	Code string `gql:"code,pattern=^[A-Z]+$"`
+}
+
+type ValidationHost struct{}
+
+func (h *ValidationHost) CreatePerson(input PersonInput) (string, error) {
+	return input.Name, nil
+}
+
+func TestValidateInput(t *testing.T) {
+	cases := []struct {
+		input PersonInput
+		err   string
+	}{
+		{input: PersonInput{Name: "john", Age: 30, Code: "AB"}},
+		{input: PersonInput{Name: "john", Age: 200}, err: "age: value 200 is greater than max 150"},
+		{input: PersonInput{Name: "john", Age: -1}, err: "age: value -1 is less than min 0"},
+		{input: PersonInput{Name: "", Age: 1}, err: "name: length 0 is less than min 1"},
+		{input: PersonInput{Name: "john", Code: "ab"}, err: `code: "ab" does not match pattern`},
+	}
+
+	for _, c := range cases {
+		err := ValidateInput(reflect.ValueOf(c.input))
+		if c.err == "" {
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
+
+func TestValidateInputResolver(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&ValidationHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ createPerson(name: "john", age: 200) }`,
+		Context:       context.Background(),
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got %v", result.Errors)
+	}
+	if !strings.Contains(result.Errors[0].Message, "greater than max 150") {
+		t.Fatalf("expected max violation, got %s", result.Errors[0].Message)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ createPerson(name: "john", age: 20) }`,
+		Context:       context.Background(),
+	})
+	if result.Errors != nil {
+		t.Fatalf("expected no errors, got %v", result.Errors)
+	}
+}
+
+type TakeInput struct {
+	Count int    `gql:"count,min=1"`
+	Code  string `gql:"code,pattern=^[a-z]{1,3}$"`
+}
+
+type TakeHost struct{}
+
+func (h *TakeHost) Take(input TakeInput) (int, error) {
+	return input.Count, nil
+}
+
+func TestValidateInputPresentArguments(t *testing.T) {
+	schema, err := NewSchemaBuilder().WithQuery(&TakeHost{}).BuildSchema()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := []struct {
+		query string
+		err   string
+	}{
+		{query: `{ take }`},
+		{query: `{ take(count: 2, code: "ab") }`},
+		{query: `{ take(count: 0) }`, err: "count: value 0 is less than min 1"},
+		{query: `{ take(count: -5) }`, err: "count: value -5 is less than min 1"},
+		{query: `{ take(code: "") }`, err: `code: "" does not match pattern`},
+		{query: `{ take(code: "abcd") }`, err: `code: "abcd" does not match pattern`},
+	}
+
+	for _, c := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:        *schema,
+			RequestString: c.query,
+			Context:       context.Background(),
+		})
+		if c.err == "" {
+			if result.Errors != nil {
+				t.Errorf("%s: expected no errors, got %v", c.query, result.Errors)
+			}
+			continue
+		}
+		if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, c.err) {
+			t.Errorf("%s: expected error containing %q, got %v", c.query, c.err, result.Errors)
+		}
+	}
+}
+
+type InvalidPatternInput struct {
+	Code string `gql:"code,pattern=[a-"`
+}
+
+type InvalidPatternHost struct{}
+
+func (h *InvalidPatternHost) Check(input InvalidPatternInput) (bool, error) {
+	return true, nil
+}
+
+func TestValidateInputInvalidPattern(t *testing.T) {
+	_, err := NewSchemaBuilder().WithQuery(&InvalidPatternHost{}).BuildSchema()
+	if err == nil || !strings.Contains(err.Error(), "Invalid gql tag of InvalidPatternInput.Code") {
+		t.Fatalf("expected an invalid tag error, got %v", err)
+	}
+}